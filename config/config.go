@@ -0,0 +1,293 @@
+// Package config contains the declarative configuration of review-bot,
+// which describes the hosters, reviewer mappings and reminder jobs.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the root of the configuration file.
+type Config struct {
+	// Hosters by their name, referenced by the jobs.
+	Hosters map[string]Hoster `yaml:"hosters"`
+	// Reviewers are named mappings from the hoster user to the chat handle.
+	Reviewers map[string]Reviewers `yaml:"reviewers"`
+	// Jobs are executed in the given order.
+	Jobs []Job `yaml:"jobs"`
+	// State is the path to the file with the ids of the previous messages,
+	// defaults to DefaultState.
+	State string `yaml:"state"`
+}
+
+// DefaultState is the path of the state file when none is configured.
+const DefaultState = "review-bot-state.json"
+
+// StateFile returns the path to the state file.
+func (c *Config) StateFile() string {
+	if c.State == "" {
+		return DefaultState
+	}
+	return c.State
+}
+
+// Hoster describes how to connect to a hoster.
+type Hoster struct {
+	Provider  string `yaml:"provider"`
+	Host      string `yaml:"host"`
+	Token     string `yaml:"token"`
+	APIURL    string `yaml:"api_url"`
+	UploadURL string `yaml:"upload_url"`
+	// Options are provider specific settings (e.g. 'api: graphql' for github).
+	Options map[string]string `yaml:"options"`
+}
+
+// Reviewers maps the hoster users to their reviewer details.
+type Reviewers map[string]Reviewer
+
+// Chats returns the chat handles by hoster user.
+func (r Reviewers) Chats() map[string]string {
+	chats := make(map[string]string, len(r))
+	for user, reviewer := range r {
+		chats[user] = reviewer.Chat
+	}
+	return chats
+}
+
+// Emails returns the email addresses by chat handle.
+func (r Reviewers) Emails() map[string]string {
+	emails := map[string]string{}
+	for _, reviewer := range r {
+		if reviewer.Email != "" {
+			emails[reviewer.Chat] = reviewer.Email
+		}
+	}
+	return emails
+}
+
+// Reviewer is either given as chat handle only (e.g. '@hulk'),
+// or with the email address as object (e.g. '{chat: "@hulk", email: hulk@example.com}').
+type Reviewer struct {
+	Chat  string `yaml:"chat" json:"chat"`
+	Email string `yaml:"email" json:"email"`
+}
+
+// UnmarshalYAML accepts the chat handle or the object.
+func (r *Reviewer) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*r = Reviewer{}
+		return node.Decode(&r.Chat)
+	}
+	type plain Reviewer
+	return node.Decode((*plain)(r))
+}
+
+// UnmarshalJSON accepts the chat handle or the object.
+func (r *Reviewer) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		*r = Reviewer{}
+		return json.Unmarshal(b, &r.Chat)
+	}
+	type plain Reviewer
+	return json.Unmarshal(b, (*plain)(r))
+}
+
+// Job describes a single reminder message.
+type Job struct {
+	Name   string `yaml:"name"`
+	Hoster string `yaml:"hoster"`
+	// Repos in the format of the hoster (e.g. 'owner/repo').
+	Repos []string `yaml:"repos"`
+	// Groups to discover further repositories.
+	Groups []string `yaml:"groups"`
+	// Filter is a regular expression the discovered repositories have to match.
+	Filter string `yaml:"filter"`
+	// Topic the discovered repositories have to be tagged with.
+	Topic string `yaml:"topic"`
+	// Reviewers is the name of a mapping in the reviewers section.
+	Reviewers string `yaml:"reviewers"`
+	// ReviewersFile is the path to a JSON reviewers file.
+	ReviewersFile string `yaml:"reviewers_file"`
+	// Template is the path to the template file, the hoster default is used when empty.
+	Template string `yaml:"template"`
+	// DigestTemplate is the path to the template file of the personal digests.
+	DigestTemplate string   `yaml:"digest_template"`
+	Notify         []Notify `yaml:"notify"`
+	// Schedule is the cron expression (e.g. '30 9 * * 1-5') when the job runs in serve mode.
+	Schedule string `yaml:"schedule"`
+	// Timezone of the schedule (e.g. 'Europe/Berlin'), defaults to the local timezone.
+	Timezone string `yaml:"timezone"`
+}
+
+// Spec returns the cron spec of the schedule including the timezone.
+func (j Job) Spec() string {
+	if j.Timezone == "" {
+		return j.Schedule
+	}
+	return fmt.Sprintf("CRON_TZ=%s %s", j.Timezone, j.Schedule)
+}
+
+// Notify describes where to send the reminder.
+type Notify struct {
+	// Type of the notifier (e.g. slack), defaults to webhook.
+	Type    string `yaml:"type"`
+	Webhook string `yaml:"webhook"`
+	// URL of the chat server (e.g. https://mattermost.example.com).
+	URL string `yaml:"url"`
+	// Token of the bot user.
+	Token   string `yaml:"token"`
+	Channel string `yaml:"channel"`
+	// Digest sends each missing reviewer a direct message with their reminders
+	// instead of posting to the channel.
+	Digest bool `yaml:"digest"`
+	// Previous is what happens with the previous message of the job in the channel,
+	// it's either edited in place (PreviousEdit) or deleted (PreviousDelete).
+	// It's kept when empty.
+	Previous string `yaml:"previous"`
+	// Options are notifier specific settings (e.g. 'blocks: false' for slack).
+	Options map[string]string `yaml:"options"`
+}
+
+// Values of Notify.Previous.
+const (
+	PreviousEdit   = "edit"
+	PreviousDelete = "delete"
+)
+
+// Kind returns the type of the notifier.
+func (n Notify) Kind() string {
+	if n.Type == "" {
+		return "webhook"
+	}
+	return n.Type
+}
+
+// Load reads the configuration file at the given path.
+// Environment variables (e.g. ${GITLAB_TOKEN}) in tokens and webhooks are expanded.
+func Load(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	return parse(b)
+}
+
+func parse(b []byte) (*Config, error) {
+	var cfg Config
+
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	cfg.expandEnv()
+
+	return &cfg, nil
+}
+
+// envVar matches the ${VAR} references of environment variables.
+var envVar = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv replaces the environment variable references in secrets.
+func (c *Config) expandEnv() {
+	for name, h := range c.Hosters {
+		h.Token = expand(h.Token)
+		c.Hosters[name] = h
+	}
+	for i := range c.Jobs {
+		for j := range c.Jobs[i].Notify {
+			c.Jobs[i].Notify[j].Webhook = expand(c.Jobs[i].Notify[j].Webhook)
+			c.Jobs[i].Notify[j].Token = expand(c.Jobs[i].Notify[j].Token)
+		}
+	}
+}
+
+// expand replaces only the braced ${VAR} form, other '$' are kept
+// as they are common in generated secrets.
+func expand(s string) string {
+	return envVar.ReplaceAllStringFunc(s, func(ref string) string {
+		return os.Getenv(envVar.FindStringSubmatch(ref)[1])
+	})
+}
+
+// Validate checks the references between the jobs, hosters and reviewers.
+// Unnamed jobs are named after their position.
+func (c *Config) Validate() error {
+	if len(c.Jobs) == 0 {
+		return errors.New("missing jobs")
+	}
+
+	names := map[string]bool{}
+
+	for i := range c.Jobs {
+		job := &c.Jobs[i]
+
+		if job.Name == "" {
+			job.Name = fmt.Sprintf("job-%d", i)
+		}
+		if names[job.Name] {
+			return fmt.Errorf("job %q defined twice", job.Name)
+		}
+		names[job.Name] = true
+
+		h, ok := c.Hosters[job.Hoster]
+		if !ok {
+			return fmt.Errorf("job %q: unknown hoster %q", job.Name, job.Hoster)
+		}
+		// azure defaults to dev.azure.com
+		if h.Host == "" && h.APIURL == "" && h.Provider != "azure" {
+			return fmt.Errorf("job %q: missing host of hoster %q", job.Name, job.Hoster)
+		}
+		if len(job.Repos) == 0 && len(job.Groups) == 0 {
+			return fmt.Errorf("job %q: missing repository or group", job.Name)
+		}
+		if job.Filter != "" {
+			if _, err := regexp.Compile(job.Filter); err != nil {
+				return fmt.Errorf("job %q: failed to compile filter: %w", job.Name, err)
+			}
+		}
+		if job.Reviewers != "" {
+			if _, ok := c.Reviewers[job.Reviewers]; !ok {
+				return fmt.Errorf("job %q: unknown reviewers %q", job.Name, job.Reviewers)
+			}
+		}
+		if job.Reviewers != "" && job.ReviewersFile != "" {
+			return fmt.Errorf("job %q: either set reviewers or reviewers_file", job.Name)
+		}
+		for _, n := range job.Notify {
+			if n.Kind() == "webhook" && n.Webhook == "" {
+				return fmt.Errorf("job %q: missing webhook", job.Name)
+			}
+			switch n.Previous {
+			case "", PreviousEdit, PreviousDelete:
+			default:
+				return fmt.Errorf("job %q: invalid previous %q (options: %v, %v)", job.Name, n.Previous, PreviousEdit, PreviousDelete)
+			}
+			if n.Previous != "" && n.Digest {
+				return fmt.Errorf("job %q: previous is not supported with digests", job.Name)
+			}
+		}
+		if job.Timezone != "" {
+			if _, err := time.LoadLocation(job.Timezone); err != nil {
+				return fmt.Errorf("job %q: invalid timezone: %w", job.Name, err)
+			}
+		}
+		if job.Schedule != "" {
+			if _, err := cron.ParseStandard(job.Spec()); err != nil {
+				return fmt.Errorf("job %q: invalid schedule: %w", job.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
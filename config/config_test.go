@@ -0,0 +1,142 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Setenv("GITLAB_TOKEN", "gitlab-secret")
+	t.Setenv("MATTERMOST_WEBHOOK", "https://mattermost.example.com/hooks/xxx")
+	t.Setenv("SLACK_WEBHOOK", "https://hooks.slack.com/services/xxx")
+	t.Setenv("SLACK_BOT_TOKEN", "xoxb-secret")
+
+	cfg, err := Load("../examples/config.yaml")
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	require.Equal(t, Hoster{Provider: "gitlab", Host: "gitlab.example.com", Token: "gitlab-secret"}, cfg.Hosters["gitlab"])
+	require.Equal(t, Reviewers{"hulk51": {Chat: "@hulk"}, "tonystark": {Chat: "@iron_man"}}, cfg.Reviewers["backend"])
+	require.Equal(t, Reviewer{Chat: "@batman", Email: "batman@example.com"}, cfg.Reviewers["frontend"]["darkknight"])
+	require.Len(t, cfg.Jobs, 2)
+
+	want := Job{
+		Name:      "backend",
+		Hoster:    "gitlab",
+		Repos:     []string{"avengers/helicarrier", "avengers/shield"},
+		Groups:    []string{"avengers/services"},
+		Filter:    "-service$",
+		Reviewers: "backend",
+		Template:  "examples/gitlab_mattermost.tmpl",
+		Notify:    []Notify{{Webhook: "https://mattermost.example.com/hooks/xxx", Channel: "backend"}},
+		Schedule:  "30 9 * * 1-5",
+		Timezone:  "Europe/Berlin",
+	}
+	require.Equal(t, want, cfg.Jobs[0])
+
+	require.Equal(t, []Notify{
+		{Webhook: "https://hooks.slack.com/services/xxx"},
+		{Type: "slack", Token: "xoxb-secret", Digest: true},
+	}, cfg.Jobs[1].Notify)
+
+	_, err = Load("unknown.yaml")
+	require.Error(t, err)
+}
+
+func TestReviewers(t *testing.T) {
+	var reviewers Reviewers
+	require.NoError(t, json.Unmarshal([]byte(`{"hulk51":"@hulk","darkknight":{"chat":"@batman","email":"batman@example.com"}}`), &reviewers))
+	require.Equal(t, Reviewers{"hulk51": {Chat: "@hulk"}, "darkknight": {Chat: "@batman", Email: "batman@example.com"}}, reviewers)
+
+	require.Equal(t, map[string]string{"hulk51": "@hulk", "darkknight": "@batman"}, reviewers.Chats())
+	require.Equal(t, map[string]string{"@batman": "batman@example.com"}, reviewers.Emails())
+
+	require.Error(t, json.Unmarshal([]byte(`{"hulk51":1}`), &reviewers))
+}
+
+func TestParse(t *testing.T) {
+	_, err := parse([]byte("unknown: field"))
+	require.Error(t, err)
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("GITLAB_TOKEN", "gitlab-secret")
+	t.Setenv("abc", "expanded")
+
+	cfg, err := parse([]byte(`
+hosters:
+  gitlab:
+    host: gitlab.com
+    token: ${GITLAB_TOKEN}
+jobs:
+  - hoster: gitlab
+    notify:
+      - type: smtp
+        token: pa$abc$$word${abc}
+        webhook: https://example.com/$abc
+`))
+	require.NoError(t, err)
+
+	require.Equal(t, "gitlab-secret", cfg.Hosters["gitlab"].Token)
+	require.Equal(t, "pa$abc$$wordexpanded", cfg.Jobs[0].Notify[0].Token)
+	require.Equal(t, "https://example.com/$abc", cfg.Jobs[0].Notify[0].Webhook)
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *Config {
+		return &Config{
+			Hosters:   map[string]Hoster{"gitlab": {Host: "gitlab.com"}},
+			Reviewers: map[string]Reviewers{"team": {}},
+			Jobs:      []Job{{Hoster: "gitlab", Repos: []string{"owner/repo"}, Reviewers: "team"}},
+		}
+	}
+
+	cfg := valid()
+	require.NoError(t, cfg.Validate())
+	require.Equal(t, "job-0", cfg.Jobs[0].Name)
+
+	cfg = valid()
+	cfg.Jobs[0].Notify = []Notify{{Type: "slack", Token: "xoxb-secret", Channel: "C024BE91L", Previous: PreviousEdit}}
+	require.NoError(t, cfg.Validate())
+	require.Equal(t, DefaultState, cfg.StateFile())
+
+	cfg = valid()
+	cfg.Hosters["gitlab"] = Hoster{Provider: "azure"}
+	require.NoError(t, cfg.Validate(), "azure without host")
+
+	tests := map[string]func(c *Config){
+		"no jobs":          func(c *Config) { c.Jobs = nil },
+		"duplicate name":   func(c *Config) { c.Jobs[0].Name = "job"; c.Jobs = append(c.Jobs, c.Jobs[0]) },
+		"unknown hoster":   func(c *Config) { c.Jobs[0].Hoster = "unknown" },
+		"missing host":     func(c *Config) { c.Hosters["gitlab"] = Hoster{} },
+		"missing repo":     func(c *Config) { c.Jobs[0].Repos = nil },
+		"invalid filter":   func(c *Config) { c.Jobs[0].Filter = "(" },
+		"unknown mapping":  func(c *Config) { c.Jobs[0].Reviewers = "unknown" },
+		"mapping and file": func(c *Config) { c.Jobs[0].ReviewersFile = "reviewers.json" },
+		"missing webhook":  func(c *Config) { c.Jobs[0].Notify = []Notify{{Channel: "channel"}} },
+		"invalid previous": func(c *Config) { c.Jobs[0].Notify = []Notify{{Type: "slack", Previous: "keep"}} },
+		"previous digest":  func(c *Config) { c.Jobs[0].Notify = []Notify{{Type: "slack", Previous: PreviousEdit, Digest: true}} },
+		"invalid timezone": func(c *Config) { c.Jobs[0].Timezone = "Mars/Olympus" },
+		"invalid schedule": func(c *Config) { c.Jobs[0].Schedule = "every day" },
+	}
+
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := valid()
+			modify(cfg)
+			require.Error(t, cfg.Validate())
+		})
+	}
+}
+
+func TestSpec(t *testing.T) {
+	require.Equal(t, "30 9 * * 1-5", Job{Schedule: "30 9 * * 1-5"}.Spec())
+	require.Equal(t, "CRON_TZ=Europe/Berlin 30 9 * * 1-5", Job{Schedule: "30 9 * * 1-5", Timezone: "Europe/Berlin"}.Spec())
+}
+
+func TestNotifyKind(t *testing.T) {
+	require.Equal(t, "webhook", Notify{}.Kind())
+	require.Equal(t, "slack", Notify{Type: "slack"}.Kind())
+}
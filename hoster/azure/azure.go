@@ -0,0 +1,166 @@
+package azure
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/sj14/review-bot/hoster"
+)
+
+func init() {
+	hoster.Register("azure", New)
+}
+
+// host implements hoster.Hoster for Azure DevOps Repos.
+type host struct {
+	git clientWrapper
+}
+
+// New returns an Azure DevOps hoster for the given host address
+// (e.g. dev.azure.com or an Azure DevOps Server address including the path).
+func New(cfg hoster.Config) (hoster.Hoster, error) {
+	// no options supported
+	if err := cfg.Options.Check(); err != nil {
+		return nil, err
+	}
+
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		addr := cfg.Host
+		if addr == "" {
+			addr = "dev.azure.com"
+		}
+		baseURL = fmt.Sprintf("https://%s", addr)
+	}
+
+	return &host{git: newClient(baseURL, cfg.Token)}, nil
+}
+
+// AggregateReminder will generate the reminders of the given repository
+// (format: 'organization/project/repo', or 'collection/project/repo' for Azure DevOps Server).
+func (h *host) AggregateReminder(repo string, reviewers map[string]string) (hoster.Repository, []hoster.Reminder, error) {
+	parts := strings.Split(repo, "/")
+	if len(parts) != 3 {
+		return hoster.Repository{}, nil, fmt.Errorf("wrong repo format %q (use 'organization/project/repo')", repo)
+	}
+
+	// the API is scoped to the organization and project
+	return aggregate(h.git, parts[0]+"/"+parts[1], parts[2], reviewers)
+}
+
+// DefaultTemplate returns the Azure DevOps default template.
+func (h *host) DefaultTemplate() *template.Template {
+	return DefaultTemplate()
+}
+
+// NotifierTemplate returns the Azure DevOps default template of the notifier.
+func (h *host) NotifierTemplate(notifier string) *template.Template {
+	return hoster.DefaultNotifierTemplate(notifier, teamsHowTo)
+}
+
+// helper functions for easier testability (mocked azure client)
+func aggregate(git clientWrapper, project, repo string, reviewers map[string]string) (hoster.Repository, []hoster.Reminder, error) {
+	repository, err := git.loadRepository(project, repo)
+	if err != nil {
+		return hoster.Repository{}, nil, err
+	}
+
+	pullRequests, err := git.loadPRs(project, repo)
+	if err != nil {
+		return hoster.Repository{}, nil, err
+	}
+
+	var reminders []hoster.Reminder
+
+	for _, pr := range pullRequests {
+		// don't check draft PRs
+		if pr.IsDraft {
+			continue
+		}
+
+		threads, err := git.loadThreads(project, repo, pr.PullRequestID)
+		if err != nil {
+			return hoster.Repository{}, nil, err
+		}
+
+		reminders = append(reminders, hoster.Reminder{
+			Number:      pr.PullRequestID,
+			Title:       pr.Title,
+			URL:         fmt.Sprintf("%s/pullrequest/%d", repository.WebURL, pr.PullRequestID),
+			Author:      pr.CreatedBy.UniqueName,
+			CreatedAt:   pr.CreationDate,
+			Missing:     missingReviewers(pr.Reviewers, reviewers),
+			Discussions: activeThreadsCount(threads),
+			Owner:       responsiblePerson(pr, reviewers),
+		})
+	}
+
+	return hoster.Repository{
+		Name: repository.Name,
+		URL:  repository.WebURL,
+	}, reminders, nil
+}
+
+// Reviewer votes of Azure DevOps.
+const (
+	approved                = 10
+	approvedWithSuggestions = 5
+	noVote                  = 0
+	waitingForAuthor        = -5
+	rejected                = -10
+)
+
+// missingReviewers returns the chat names of the reviewers who haven't voted yet.
+// Any vote (approve, approve with suggestions, wait for author, reject) counts as reviewed.
+// Reviewers without a mapping are returned with their display name.
+// Groups (e.g. '[Project]\Team') are skipped, their members are only reminded when requested themselves.
+func missingReviewers(requested []reviewer, mapping map[string]string) []string {
+	var missing []string
+
+	for _, r := range requested {
+		if r.Vote != noVote || r.IsContainer {
+			continue
+		}
+
+		if name, ok := mapping[r.UniqueName]; ok {
+			missing = append(missing, name)
+			continue
+		}
+		// missing chat name mapping, use display name as fallback
+		missing = append(missing, r.DisplayName)
+	}
+
+	return missing
+}
+
+// Thread states of Azure DevOps which are still open.
+const (
+	active  = "active"
+	pending = "pending"
+)
+
+// activeThreadsCount returns the number of open comment threads.
+// System threads (e.g. "pushed new commits") don't have a status and are ignored.
+func activeThreadsCount(threads []thread) int {
+	count := 0
+	for _, t := range threads {
+		if t.IsDeleted {
+			continue
+		}
+		if t.Status == active || t.Status == pending {
+			count++
+		}
+	}
+	return count
+}
+
+// responsiblePerson returns the chat name of the creator of the PR
+// (fallback: azure display name of the creator)
+func responsiblePerson(pr pullRequest, reviewers map[string]string) string {
+	if author, ok := reviewers[pr.CreatedBy.UniqueName]; ok {
+		return author
+	}
+
+	return pr.CreatedBy.DisplayName
+}
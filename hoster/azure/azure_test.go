@@ -0,0 +1,101 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/sj14/review-bot/hoster"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateReminder(t *testing.T) {
+	mockedClient := &clientWrapperMock{
+		loadRepositoryFunc: func(project, repo string) (repository, error) {
+			return repository{Name: "mocked repo", WebURL: "https://dev.azure.com/org/project/_git/repo"}, nil
+		},
+		loadPRsFunc: func(project, repo string) ([]pullRequest, error) {
+			return []pullRequest{
+				{
+					PullRequestID: 1,
+					Title:         "PR0",
+					CreatedBy:     identity{UniqueName: "author@example.com"},
+					Reviewers: []reviewer{
+						{identity: identity{UniqueName: "user0@example.com"}, Vote: noVote},
+						{identity: identity{UniqueName: "user1@example.com"}, Vote: approved},
+					},
+				},
+				{PullRequestID: 2, Title: "PR1", IsDraft: true},
+			}, nil
+		},
+		loadThreadsFunc: func(project, repo string, id int) ([]thread, error) {
+			return []thread{{Status: active}, {Status: "fixed"}}, nil
+		},
+	}
+
+	expR := []hoster.Reminder{
+		{
+			Number:      1,
+			Title:       "PR0",
+			URL:         "https://dev.azure.com/org/project/_git/repo/pullrequest/1",
+			Author:      "author@example.com",
+			Missing:     []string{"@user0"},
+			Discussions: 1,
+			Owner:       "@author",
+		},
+	}
+
+	gotP, gotR, err := aggregate(mockedClient, "org/project", "repo", map[string]string{"user0@example.com": "@user0", "author@example.com": "@author"})
+
+	require.NoError(t, err)
+	require.Equal(t, hoster.Repository{Name: "mocked repo", URL: "https://dev.azure.com/org/project/_git/repo"}, gotP)
+	require.Equal(t, expR, gotR)
+	require.Len(t, mockedClient.loadThreadsCalls(), 1)
+}
+
+func TestMissingReviewers(t *testing.T) {
+	requested := []reviewer{
+		{identity: identity{UniqueName: "user0", DisplayName: "User 0"}, Vote: approved},
+		{identity: identity{UniqueName: "user1", DisplayName: "User 1"}, Vote: approvedWithSuggestions},
+		{identity: identity{UniqueName: "user2", DisplayName: "User 2"}, Vote: waitingForAuthor},
+		{identity: identity{UniqueName: "user3", DisplayName: "User 3"}, Vote: rejected},
+		{identity: identity{UniqueName: "user4", DisplayName: "User 4"}, Vote: noVote},
+		{identity: identity{UniqueName: "user5", DisplayName: "User 5"}, Vote: noVote},
+		{identity: identity{UniqueName: "vstfs:///Classification/TeamProject/team", DisplayName: `[Project]\Team`}, Vote: noVote, IsContainer: true},
+	}
+	mapping := map[string]string{
+		"user0": "@user0",
+		"user4": "@user4",
+		// "user5": "@user5", // Test for fallback to display name on missing mapping
+	}
+
+	got := missingReviewers(requested, mapping)
+
+	want := []string{"@user4", "User 5"}
+	require.Equal(t, want, got)
+}
+
+func TestActiveThreadsCount(t *testing.T) {
+	threads := []thread{
+		{Status: active},
+		{Status: pending},
+		{Status: active, IsDeleted: true},
+		{Status: "fixed"},
+		{Status: "closed"},
+		{}, // system thread
+	}
+
+	require.Equal(t, 2, activeThreadsCount(threads))
+}
+
+func TestResponsiblePerson(t *testing.T) {
+	reviewers := map[string]string{"author": "@author"}
+
+	t.Run("mapping", func(t *testing.T) {
+		pr := pullRequest{CreatedBy: identity{UniqueName: "author"}}
+		require.Equal(t, "@author", responsiblePerson(pr, reviewers))
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		pr := pullRequest{CreatedBy: identity{UniqueName: "unknown", DisplayName: "Un Known"}}
+		require.Equal(t, "Un Known", responsiblePerson(pr, reviewers))
+	})
+}
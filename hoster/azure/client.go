@@ -0,0 +1,136 @@
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sj14/review-bot/hoster"
+)
+
+const (
+	perPage    = 25
+	apiVersion = "7.1"
+)
+
+//go:generate go tool moq -out client_moq_test.go . clientWrapper
+type clientWrapper interface {
+	loadRepository(project, repo string) (repository, error)
+	loadPRs(project, repo string) ([]pullRequest, error)
+	loadThreads(project, repo string, id int) ([]thread, error)
+}
+
+type identity struct {
+	UniqueName  string `json:"uniqueName"`
+	DisplayName string `json:"displayName"`
+}
+
+type reviewer struct {
+	identity
+	Vote        int  `json:"vote"`
+	IsContainer bool `json:"isContainer"`
+}
+
+type repository struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	WebURL string `json:"webUrl"`
+}
+
+type pullRequest struct {
+	PullRequestID int        `json:"pullRequestId"`
+	Title         string     `json:"title"`
+	IsDraft       bool       `json:"isDraft"`
+	CreatedBy     identity   `json:"createdBy"`
+	CreationDate  time.Time  `json:"creationDate"`
+	Reviewers     []reviewer `json:"reviewers"`
+}
+
+type thread struct {
+	ID        int    `json:"id"`
+	Status    string `json:"status"`
+	IsDeleted bool   `json:"isDeleted"`
+}
+
+// list is the envelope of all list responses.
+type list[T any] struct {
+	Value []T `json:"value"`
+	Count int `json:"count"`
+}
+
+type client struct {
+	rest *hoster.RESTClient
+}
+
+// newClient returns a new azure devops client for the given URL (e.g. https://dev.azure.com).
+func newClient(baseURL, token string) *client {
+	return &client{rest: hoster.NewRESTClient(baseURL, func(req *http.Request) {
+		if token != "" {
+			// personal access tokens are sent as password with an empty user name
+			req.SetBasicAuth("", token)
+		}
+	})}
+}
+
+// get decodes the response of the given API path into v.
+func (c *client) get(path string, query url.Values, v interface{}) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("api-version", apiVersion)
+
+	return c.rest.Get(path, query, v)
+}
+
+// repoPath returns the API path of the repository within the given 'organization/project'.
+func repoPath(project, repo string) string {
+	var segments []string
+	for _, s := range strings.Split(project, "/") {
+		segments = append(segments, url.PathEscape(s))
+	}
+	return fmt.Sprintf("/%s/_apis/git/repositories/%s", strings.Join(segments, "/"), url.PathEscape(repo))
+}
+
+func (c *client) loadRepository(project, repo string) (repository, error) {
+	var r repository
+	if err := c.get(repoPath(project, repo), nil, &r); err != nil {
+		return repository{}, fmt.Errorf("failed loading repo: %w", err)
+	}
+	return r, nil
+}
+
+func (c *client) loadPRs(project, repo string) ([]pullRequest, error) {
+	var (
+		pullRequests []pullRequest
+		query        = url.Values{
+			"searchCriteria.status": {"active"},
+			"$top":                  {fmt.Sprint(perPage)},
+		}
+	)
+
+	for skip := 0; ; skip += perPage {
+		query.Set("$skip", fmt.Sprint(skip))
+
+		var page list[pullRequest]
+		if err := c.get(repoPath(project, repo)+"/pullrequests", query, &page); err != nil {
+			return nil, fmt.Errorf("failed loading pull requests: %w", err)
+		}
+		pullRequests = append(pullRequests, page.Value...)
+
+		if len(page.Value) < perPage {
+			break
+		}
+	}
+
+	return pullRequests, nil
+}
+
+func (c *client) loadThreads(project, repo string, id int) ([]thread, error) {
+	var threads list[thread]
+	if err := c.get(fmt.Sprintf("%s/pullRequests/%d/threads", repoPath(project, repo), id), nil, &threads); err != nil {
+		return nil, fmt.Errorf("failed loading threads of PR %v: %w", id, err)
+	}
+	return threads.Value, nil
+}
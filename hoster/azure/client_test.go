@@ -0,0 +1,44 @@
+package azure
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sj14/review-bot/hoster"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /org/my project/_apis/git/repositories/repo", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte(":secret")), r.Header.Get("Authorization"))
+		require.Equal(t, apiVersion, r.URL.Query().Get("api-version"))
+		fmt.Fprint(w, `{"name":"repo","webUrl":"https://dev.azure.com/org/my%20project/_git/repo"}`)
+	})
+	mux.HandleFunc("GET /org/my project/_apis/git/repositories/repo/pullrequests", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "active", r.URL.Query().Get("searchCriteria.status"))
+		fmt.Fprint(w, `{"count":1,"value":[{"pullRequestId":7,"title":"PR","reviewers":[{"uniqueName":"user0","displayName":"User 0","vote":-5}]}]}`)
+	})
+	mux.HandleFunc("GET /org/my project/_apis/git/repositories/repo/pullRequests/7/threads", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"count":2,"value":[{"id":1,"status":"active"},{"id":2}]}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	h, err := New(hoster.Config{Token: "secret", BaseURL: srv.URL})
+	require.NoError(t, err)
+
+	repository, reminders, err := h.AggregateReminder("org/my project/repo", nil)
+	require.NoError(t, err)
+	require.Equal(t, "repo", repository.Name)
+	require.Len(t, reminders, 1)
+	require.Equal(t, 1, reminders[0].Discussions)
+	require.Empty(t, reminders[0].Missing)
+
+	_, _, err = h.AggregateReminder("org/repo", nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,23 @@
+package azure
+
+import "text/template"
+
+// DefaultTemplate contains a project header and reminder messages.
+func DefaultTemplate() *template.Template {
+	const defaultTemplate = `
+# [{{.Repository.Name}}]({{.Repository.URL}})
+
+**How-To**: *Got reminded? Just vote on the given pull request (approve, wait for author or reject).*
+
+---
+
+{{range .Reminders}}
+**[{{.Title}}]({{.URL}})**
+{{if .Discussions}} {{.Discussions}} 💬 {{end}} {{range .Missing}}{{.}} {{else}}You got all reviews, {{.Owner}}.{{end}}
+{{end}}
+`
+	return template.Must(template.New("default").Parse(defaultTemplate))
+}
+
+// teamsHowTo tells the reminded reviewers of the Teams template what to do.
+const teamsHowTo = "Got reminded? Just vote on the given pull request (approve, wait for author or reject)."
@@ -0,0 +1,169 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sj14/review-bot/hoster"
+)
+
+func init() {
+	hoster.Register("bitbucket", New)
+}
+
+// host implements hoster.Hoster for Bitbucket Server and Data Center.
+type host struct {
+	git clientWrapper
+}
+
+// New returns a Bitbucket Server/Data Center hoster for the given host address (e.g. bitbucket.example.com).
+func New(cfg hoster.Config) (hoster.Hoster, error) {
+	// no options supported
+	if err := cfg.Options.Check(); err != nil {
+		return nil, err
+	}
+
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("https://%s/rest/api/1.0", cfg.Host)
+	}
+
+	return &host{git: newClient(baseURL, cfg.Token)}, nil
+}
+
+// AggregateReminder will generate the reminders of the given repository (format: 'PROJECT/repo').
+func (h *host) AggregateReminder(repo string, reviewers map[string]string) (hoster.Repository, []hoster.Reminder, error) {
+	projectRepo := strings.SplitN(repo, "/", 2)
+	if len(projectRepo) != 2 {
+		return hoster.Repository{}, nil, fmt.Errorf("wrong repo format %q (use 'PROJECT/repo')", repo)
+	}
+
+	return aggregate(h.git, projectRepo[0], projectRepo[1], reviewers)
+}
+
+// DefaultTemplate returns the Bitbucket default template.
+func (h *host) DefaultTemplate() *template.Template {
+	return DefaultTemplate()
+}
+
+// NotifierTemplate returns the Bitbucket default template of the notifier.
+func (h *host) NotifierTemplate(notifier string) *template.Template {
+	return hoster.DefaultNotifierTemplate(notifier, teamsHowTo)
+}
+
+// helper functions for easier testability (mocked bitbucket client)
+func aggregate(git clientWrapper, project, repo string, reviewers map[string]string) (hoster.Repository, []hoster.Reminder, error) {
+	repository, err := git.loadRepository(project, repo)
+	if err != nil {
+		return hoster.Repository{}, nil, err
+	}
+
+	pullRequests, err := git.loadPRs(project, repo)
+	if err != nil {
+		return hoster.Repository{}, nil, err
+	}
+
+	var reminders []hoster.Reminder
+
+	for _, pr := range pullRequests {
+		// don't check draft PRs
+		if pr.Draft {
+			continue
+		}
+
+		reminders = append(reminders, hoster.Reminder{
+			Number:      pr.ID,
+			Title:       pr.Title,
+			URL:         pr.Links.href(),
+			Author:      pr.Author.User.Name,
+			CreatedAt:   createdAt(pr),
+			Missing:     missingReviewers(pr, reviewers),
+			Discussions: pr.Properties.CommentCount,
+			Owner:       responsiblePerson(pr, reviewers),
+		})
+	}
+
+	return hoster.Repository{
+		Name: repository.Name,
+		URL:  repository.Links.href(),
+	}, reminders, nil
+}
+
+// createdAt returns the creation time of the PR.
+func createdAt(pr pullRequest) time.Time {
+	if pr.CreatedDate == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(pr.CreatedDate)
+}
+
+const (
+	approved  = "APPROVED"
+	needsWork = "NEEDS_WORK"
+)
+
+// isReviewed returns true when the participant approved or requested changes.
+func isReviewed(p participant) bool {
+	return p.Status == approved || p.Status == needsWork
+}
+
+// getReviewed returns the user names of all reviewers and participants who have already reviewed the PR.
+func getReviewed(pr pullRequest) []string {
+	var reviewedBy []string
+
+	for _, participants := range [][]participant{pr.Reviewers, pr.Participants} {
+		for _, p := range participants {
+			if isReviewed(p) {
+				reviewedBy = append(reviewedBy, p.User.Name)
+			}
+		}
+	}
+
+	return reviewedBy
+}
+
+// missingReviewers returns the chat names of the reviewers who haven't approved or requested changes yet.
+// Reviewers without a mapping are returned with their bitbucket user name.
+func missingReviewers(pr pullRequest, mapping map[string]string) []string {
+	reviewedBy := getReviewed(pr)
+
+	var missing []string
+
+	for _, r := range pr.Reviewers {
+		reviewed := false
+		for _, name := range reviewedBy {
+			if r.User.Name == name {
+				reviewed = true
+				break
+			}
+		}
+		if reviewed {
+			continue
+		}
+
+		if name, ok := mapping[r.User.Name]; ok {
+			missing = append(missing, name)
+			continue
+		}
+		// missing chat name mapping, use bitbucket user name as fallback
+		missing = append(missing, r.User.Name)
+	}
+
+	return missing
+}
+
+// responsiblePerson returns the chat name of the author of the PR
+// (fallback: bitbucket display name of the author)
+func responsiblePerson(pr pullRequest, reviewers map[string]string) string {
+	if author, ok := reviewers[pr.Author.User.Name]; ok {
+		return author
+	}
+
+	if pr.Author.User.DisplayName != "" {
+		return pr.Author.User.DisplayName
+	}
+
+	return pr.Author.User.Name
+}
@@ -0,0 +1,98 @@
+package bitbucket
+
+import (
+	"testing"
+
+	"github.com/sj14/review-bot/hoster"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateReminder(t *testing.T) {
+	mockedClient := &clientWrapperMock{
+		loadRepositoryFunc: func(project, repo string) (repository, error) {
+			return repository{Name: "mocked repo"}, nil
+		},
+		loadPRsFunc: func(project, repo string) ([]pullRequest, error) {
+			pr := pullRequest{
+				ID:     1,
+				Title:  "PR0",
+				Author: participant{User: user{Name: "author"}},
+				Reviewers: []participant{
+					{User: user{Name: "user0"}, Status: "UNAPPROVED"},
+					{User: user{Name: "user1"}, Status: approved},
+				},
+			}
+			pr.Properties.CommentCount = 2
+			return []pullRequest{pr, {ID: 2, Title: "PR1", Draft: true}}, nil
+		},
+	}
+
+	expR := []hoster.Reminder{
+		{Number: 1, Title: "PR0", Author: "author", Missing: []string{"@user0"}, Discussions: 2, Owner: "@author"},
+	}
+
+	gotP, gotR, err := aggregate(mockedClient, "PROJECT", "repo", map[string]string{"user0": "@user0", "author": "@author"})
+
+	require.NoError(t, err)
+	require.Equal(t, hoster.Repository{Name: "mocked repo"}, gotP)
+	require.Equal(t, expR, gotR)
+}
+
+func TestGetReviewed(t *testing.T) {
+	pr := pullRequest{
+		Reviewers: []participant{
+			{User: user{Name: "user0"}, Status: approved},
+			{User: user{Name: "user1"}, Status: needsWork},
+			{User: user{Name: "user2"}, Status: "UNAPPROVED"},
+		},
+		Participants: []participant{
+			{User: user{Name: "user3"}, Status: approved},
+			{User: user{Name: "user4"}, Status: "UNAPPROVED"},
+		},
+	}
+
+	// spare capacity must not be overwritten by the participants
+	spare := append(make([]participant, 0, 5), pr.Reviewers...)
+	pr.Reviewers = spare[:3]
+
+	got := getReviewed(pr)
+
+	want := []string{"user0", "user1", "user3"}
+	require.Equal(t, want, got)
+	require.Zero(t, spare[:4][3])
+}
+
+func TestMissingReviewers(t *testing.T) {
+	pr := pullRequest{
+		Reviewers: []participant{
+			{User: user{Name: "user0"}, Status: "UNAPPROVED"},
+			{User: user{Name: "user1"}, Status: approved},
+			{User: user{Name: "user2"}, Status: needsWork},
+			{User: user{Name: "user3"}, Status: "UNAPPROVED"},
+		},
+	}
+	mapping := map[string]string{
+		"user0": "@user0",
+		"user1": "@user1",
+		// "user3": "@user3", // Test for fallback to bitbucket user name on missing mapping
+	}
+
+	got := missingReviewers(pr, mapping)
+
+	want := []string{"@user0", "user3"}
+	require.Equal(t, want, got)
+}
+
+func TestResponsiblePerson(t *testing.T) {
+	reviewers := map[string]string{"author": "@author"}
+
+	t.Run("mapping", func(t *testing.T) {
+		pr := pullRequest{Author: participant{User: user{Name: "author"}}}
+		require.Equal(t, "@author", responsiblePerson(pr, reviewers))
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		pr := pullRequest{Author: participant{User: user{Name: "unknown", DisplayName: "Un Known"}}}
+		require.Equal(t, "Un Known", responsiblePerson(pr, reviewers))
+	})
+}
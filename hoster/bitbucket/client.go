@@ -0,0 +1,126 @@
+package bitbucket
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/sj14/review-bot/hoster"
+)
+
+const perPage = 25
+
+//go:generate go tool moq -out client_moq_test.go . clientWrapper
+type clientWrapper interface {
+	loadRepository(project, repo string) (repository, error)
+	loadPRs(project, repo string) ([]pullRequest, error)
+}
+
+type links struct {
+	Self []struct {
+		Href string `json:"href"`
+	} `json:"self"`
+}
+
+// href returns the first self link.
+func (l links) href() string {
+	if len(l.Self) == 0 {
+		return ""
+	}
+	return l.Self[0].Href
+}
+
+type user struct {
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	DisplayName string `json:"displayName"`
+}
+
+type participant struct {
+	User   user   `json:"user"`
+	Role   string `json:"role"`
+	Status string `json:"status"`
+}
+
+type repository struct {
+	Slug  string `json:"slug"`
+	Name  string `json:"name"`
+	Links links  `json:"links"`
+}
+
+type pullRequest struct {
+	ID           int           `json:"id"`
+	Title        string        `json:"title"`
+	Draft        bool          `json:"draft"`
+	CreatedDate  int64         `json:"createdDate"`
+	Author       participant   `json:"author"`
+	Reviewers    []participant `json:"reviewers"`
+	Participants []participant `json:"participants"`
+	Links        links         `json:"links"`
+	Properties   struct {
+		CommentCount int `json:"commentCount"`
+	} `json:"properties"`
+}
+
+// page is a single page of a paged API response.
+type page[T any] struct {
+	Values        []T  `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+type client struct {
+	rest *hoster.RESTClient
+}
+
+// newClient returns a new bitbucket client for the given API URL (e.g. https://bitbucket.example.com/rest/api/1.0).
+func newClient(baseURL, token string) *client {
+	return &client{rest: hoster.NewRESTClient(baseURL, func(req *http.Request) {
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	})}
+}
+
+// getAll loads all pages of the given API path.
+func getAll[T any](c *client, path string, query url.Values) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("limit", fmt.Sprint(perPage))
+
+	var all []T
+	for start := 0; ; {
+		query.Set("start", fmt.Sprint(start))
+
+		var p page[T]
+		if err := c.rest.Get(path, query, &p); err != nil {
+			return nil, err
+		}
+		all = append(all, p.Values...)
+
+		// also stop when the next page doesn't advance, to not loop forever
+		if p.IsLastPage || p.NextPageStart <= start {
+			break
+		}
+		start = p.NextPageStart
+	}
+
+	return all, nil
+}
+
+func (c *client) loadRepository(project, repo string) (repository, error) {
+	var r repository
+	if err := c.rest.Get(fmt.Sprintf("/projects/%s/repos/%s", url.PathEscape(project), url.PathEscape(repo)), nil, &r); err != nil {
+		return repository{}, fmt.Errorf("failed loading repo: %w", err)
+	}
+	return r, nil
+}
+
+func (c *client) loadPRs(project, repo string) ([]pullRequest, error) {
+	prs, err := getAll[pullRequest](c, fmt.Sprintf("/projects/%s/repos/%s/pull-requests", url.PathEscape(project), url.PathEscape(repo)), url.Values{"state": {"OPEN"}})
+	if err != nil {
+		return nil, fmt.Errorf("failed loading pull requests: %w", err)
+	}
+	return prs, nil
+}
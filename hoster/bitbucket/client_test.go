@@ -0,0 +1,51 @@
+package bitbucket
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/api/1.0/projects/PROJECT/repos/repo/pull-requests", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		require.Equal(t, "OPEN", r.URL.Query().Get("state"))
+
+		if r.URL.Query().Get("start") == "0" {
+			fmt.Fprint(w, `{"values":[{"id":1,"title":"PR0"}],"isLastPage":false,"nextPageStart":1}`)
+			return
+		}
+		fmt.Fprint(w, `{"values":[{"id":2,"title":"PR1","reviewers":[{"user":{"name":"user0"},"status":"NEEDS_WORK"}],"properties":{"commentCount":3,"openTaskCount":1}}],"isLastPage":true}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := newClient(srv.URL+"/rest/api/1.0", "secret")
+
+	prs, err := c.loadPRs("PROJECT", "repo")
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	require.Equal(t, needsWork, prs[1].Reviewers[0].Status)
+	require.Equal(t, 3, prs[1].Properties.CommentCount)
+
+	_, err = c.loadRepository("PROJECT", "unknown")
+	require.Error(t, err)
+}
+
+func TestClientStuckPagination(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"values":[{"id":1,"title":"PR0"}],"isLastPage":false,"nextPageStart":0}`)
+	}))
+	defer srv.Close()
+
+	c := newClient(srv.URL, "secret")
+
+	prs, err := c.loadPRs("PROJECT", "repo")
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+}
@@ -0,0 +1,79 @@
+package hoster
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/template"
+)
+
+// Digest contains the reminders of a single reviewer across all repositories.
+type Digest struct {
+	// Reviewer is the chat handle of the missing reviewer (e.g. @john).
+	Reviewer string
+	// Projects only contain the reminders the reviewer is missing on.
+	Projects []Project
+}
+
+// Count returns the number of reminders of the digest.
+func (d Digest) Count() int {
+	count := 0
+	for _, p := range d.Projects {
+		count += len(p.Reminders)
+	}
+	return count
+}
+
+// Digests inverts the reminders of the projects into a digest per missing reviewer,
+// sorted by the reviewer.
+func Digests(projects []Project) []Digest {
+	var (
+		digests []Digest
+		index   = make(map[string]int)
+	)
+
+	for _, p := range projects {
+		for _, r := range p.Reminders {
+			for _, reviewer := range r.Missing {
+				i, ok := index[reviewer]
+				if !ok {
+					i = len(digests)
+					index[reviewer] = i
+					digests = append(digests, Digest{Reviewer: reviewer})
+				}
+
+				d := &digests[i]
+				if n := len(d.Projects); n == 0 || d.Projects[n-1].Repository != p.Repository {
+					d.Projects = append(d.Projects, Project{Repository: p.Repository})
+				}
+				last := &d.Projects[len(d.Projects)-1]
+				last.Reminders = append(last.Reminders, r)
+			}
+		}
+	}
+
+	sort.Slice(digests, func(i, j int) bool { return digests[i].Reviewer < digests[j].Reviewer })
+	return digests
+}
+
+// DefaultDigestTemplate returns the template of the personal digests.
+func DefaultDigestTemplate() *template.Template {
+	const digestTemplate = `{{.Reviewer}}, you owe reviews on {{.Count}} {{if eq .Count 1}}request{{else}}requests{{end}} across {{len .Projects}} {{if eq (len .Projects) 1}}repository{{else}}repositories{{end}}:
+{{range .Projects}}
+**[{{.Repository.Name}}]({{.Repository.URL}})**
+{{range .Reminders}}- [{{.Title}}]({{.URL}}){{if .Discussions}} {{.Discussions}} 💬{{end}}
+{{end}}{{end}}`
+
+	return template.Must(template.New("digest").Parse(digestTemplate))
+}
+
+// ExecDigest execs the template for the given digest.
+func ExecDigest(template *template.Template, digest Digest) (string, error) {
+	buffer := bytes.NewBuffer([]byte{})
+
+	if err := template.Execute(buffer, digest); err != nil {
+		return "", fmt.Errorf("failed executing digest template: %w", err)
+	}
+
+	return buffer.String(), nil
+}
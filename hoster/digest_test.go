@@ -0,0 +1,58 @@
+package hoster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigests(t *testing.T) {
+	projects := []Project{
+		{Repository: Repository{Name: "repo0"}, Reminders: []Reminder{
+			{Title: "PR0", Missing: []string{"@hulk", "@groot"}},
+			{Title: "PR1", Missing: []string{"@hulk"}},
+			{Title: "PR2"},
+		}},
+		{Repository: Repository{Name: "repo1"}, Reminders: []Reminder{
+			{Title: "PR3", Missing: []string{"@hulk"}},
+		}},
+	}
+
+	want := []Digest{
+		{Reviewer: "@groot", Projects: []Project{
+			{Repository: Repository{Name: "repo0"}, Reminders: []Reminder{{Title: "PR0", Missing: []string{"@hulk", "@groot"}}}},
+		}},
+		{Reviewer: "@hulk", Projects: []Project{
+			{Repository: Repository{Name: "repo0"}, Reminders: []Reminder{
+				{Title: "PR0", Missing: []string{"@hulk", "@groot"}},
+				{Title: "PR1", Missing: []string{"@hulk"}},
+			}},
+			{Repository: Repository{Name: "repo1"}, Reminders: []Reminder{{Title: "PR3", Missing: []string{"@hulk"}}}},
+		}},
+	}
+
+	got := Digests(projects)
+	require.Equal(t, want, got)
+	require.Equal(t, 1, got[0].Count())
+	require.Equal(t, 3, got[1].Count())
+
+	require.Empty(t, Digests(nil))
+}
+
+func TestExecDigest(t *testing.T) {
+	digest := Digest{Reviewer: "@hulk", Projects: []Project{
+		{Repository: Repository{Name: "repo0", URL: "https://example.com/repo0"}, Reminders: []Reminder{
+			{Title: "PR0", URL: "https://example.com/repo0/1", Discussions: 2},
+			{Title: "PR1", URL: "https://example.com/repo0/2"},
+		}},
+	}}
+
+	got, err := ExecDigest(DefaultDigestTemplate(), digest)
+	require.NoError(t, err)
+	require.Equal(t, `@hulk, you owe reviews on 2 requests across 1 repository:
+
+**[repo0](https://example.com/repo0)**
+- [PR0](https://example.com/repo0/1) 2 💬
+- [PR1](https://example.com/repo0/2)
+`, got)
+}
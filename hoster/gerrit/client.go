@@ -0,0 +1,140 @@
+package gerrit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sj14/review-bot/hoster"
+)
+
+const perPage = 25
+
+//go:generate go tool moq -out client_moq_test.go . clientWrapper
+type clientWrapper interface {
+	loadProject(project string) (projectInfo, error)
+	loadChanges(project string) ([]change, error)
+}
+
+type account struct {
+	AccountID int    `json:"_account_id"`
+	Username  string `json:"username"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+}
+
+type approval struct {
+	account
+	Value int `json:"value"`
+}
+
+type label struct {
+	All []approval `json:"all"`
+}
+
+type attention struct {
+	Account account `json:"account"`
+}
+
+type projectInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type change struct {
+	Number                 int                  `json:"_number"`
+	Project                string               `json:"project"`
+	Subject                string               `json:"subject"`
+	Owner                  account              `json:"owner"`
+	Created                timestamp            `json:"created"`
+	WorkInProgress         bool                 `json:"work_in_progress"`
+	UnresolvedCommentCount int                  `json:"unresolved_comment_count"`
+	Labels                 map[string]label     `json:"labels"`
+	Reviewers              map[string][]account `json:"reviewers"`
+	AttentionSet           map[string]attention `json:"attention_set"`
+	MoreChanges            bool                 `json:"_more_changes"`
+}
+
+// timestamp is the UTC time format used by gerrit (e.g. "2013-02-01 09:59:32.126000000").
+type timestamp struct {
+	time.Time
+}
+
+const timestampLayout = "2006-01-02 15:04:05.000000000"
+
+func (t *timestamp) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	parsed, err := time.Parse(timestampLayout, s)
+	if err != nil {
+		return fmt.Errorf("failed to parse timestamp: %w", err)
+	}
+	t.Time = parsed
+	return nil
+}
+
+type client struct {
+	rest *hoster.RESTClient
+}
+
+// newClient returns a new gerrit client for the given URL (e.g. https://gerrit.example.com).
+// The token is the 'username:http-password' of the account.
+func newClient(baseURL, token string) *client {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	if token != "" {
+		// authenticated requests are prefixed with /a/
+		baseURL += "/a"
+	}
+
+	rest := hoster.NewRESTClient(baseURL, func(req *http.Request) {
+		if token != "" {
+			user, password, _ := strings.Cut(token, ":")
+			req.SetBasicAuth(user, password)
+		}
+	})
+	// strip the magic prefix )]}' which prevents XSSI
+	rest.Prefix = ")]}'"
+
+	return &client{rest: rest}
+}
+
+func (c *client) loadProject(project string) (projectInfo, error) {
+	var p projectInfo
+	if err := c.rest.Get("/projects/"+url.PathEscape(project), nil, &p); err != nil {
+		return projectInfo{}, fmt.Errorf("failed loading project: %w", err)
+	}
+	return p, nil
+}
+
+// loadChanges returns all open changes of the project which are not work in progress.
+func (c *client) loadChanges(project string) ([]change, error) {
+	var (
+		changes []change
+		query   = url.Values{
+			"q": {fmt.Sprintf("project:%q status:open -is:wip", project)},
+			"o": {"DETAILED_LABELS", "DETAILED_ACCOUNTS"},
+			"n": {fmt.Sprint(perPage)},
+		}
+	)
+
+	for {
+		query.Set("S", fmt.Sprint(len(changes)))
+
+		var page []change
+		if err := c.rest.Get("/changes/", query, &page); err != nil {
+			return nil, fmt.Errorf("failed loading changes: %w", err)
+		}
+		changes = append(changes, page...)
+
+		if len(page) == 0 || !page[len(page)-1].MoreChanges {
+			break
+		}
+	}
+
+	return changes, nil
+}
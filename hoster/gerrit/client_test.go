@@ -0,0 +1,43 @@
+package gerrit
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /a/changes/", func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "bot", user)
+		require.Equal(t, "secret", password)
+		require.Equal(t, `project:"platform/infra" status:open -is:wip`, r.URL.Query().Get("q"))
+
+		fmt.Fprintln(w, ")]}'")
+		if r.URL.Query().Get("S") == "0" {
+			fmt.Fprint(w, `[{"_number":1,"subject":"Change0","created":"2013-02-01 09:59:32.126000000","_more_changes":true}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"_number":2,"subject":"Change1","unresolved_comment_count":2}]`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := newClient(srv.URL, "bot:secret")
+
+	changes, err := c.loadChanges("platform/infra")
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	require.Equal(t, time.Date(2013, 2, 1, 9, 59, 32, 126000000, time.UTC), changes[0].Created.Time)
+	require.Equal(t, 2, changes[1].UnresolvedCommentCount)
+
+	_, err = c.loadProject("unknown")
+	require.Error(t, err)
+}
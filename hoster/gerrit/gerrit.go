@@ -0,0 +1,186 @@
+package gerrit
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/sj14/review-bot/hoster"
+)
+
+func init() {
+	hoster.Register("gerrit", New)
+}
+
+// host implements hoster.Hoster for Gerrit.
+type host struct {
+	git    clientWrapper
+	webURL string
+}
+
+// New returns a Gerrit hoster for the given host address (e.g. gerrit.example.com).
+// The token has the format 'username:http-password'.
+func New(cfg hoster.Config) (hoster.Hoster, error) {
+	// no options supported
+	if err := cfg.Options.Check(); err != nil {
+		return nil, err
+	}
+
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("https://%s", cfg.Host)
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	return &host{git: newClient(baseURL, cfg.Token), webURL: baseURL}, nil
+}
+
+// AggregateReminder will generate the reminders of the given project (e.g. 'platform/infra').
+func (h *host) AggregateReminder(repo string, reviewers map[string]string) (hoster.Repository, []hoster.Reminder, error) {
+	return aggregate(h.git, h.webURL, repo, reviewers)
+}
+
+// DefaultTemplate returns the Gerrit default template.
+func (h *host) DefaultTemplate() *template.Template {
+	return DefaultTemplate()
+}
+
+// NotifierTemplate returns the Gerrit default template of the notifier.
+func (h *host) NotifierTemplate(notifier string) *template.Template {
+	return hoster.DefaultNotifierTemplate(notifier, teamsHowTo)
+}
+
+// helper functions for easier testability (mocked gerrit client)
+func aggregate(git clientWrapper, webURL, project string, reviewers map[string]string) (hoster.Repository, []hoster.Reminder, error) {
+	p, err := git.loadProject(project)
+	if err != nil {
+		return hoster.Repository{}, nil, err
+	}
+
+	changes, err := git.loadChanges(project)
+	if err != nil {
+		return hoster.Repository{}, nil, err
+	}
+
+	var reminders []hoster.Reminder
+
+	for _, c := range changes {
+		// don't check WIP changes
+		if c.WorkInProgress {
+			continue
+		}
+
+		reviewedBy := getReviewed(c)
+		missing := missingReviewers(requestedReviewers(c), reviewedBy, reviewers)
+
+		reminders = append(reminders, hoster.Reminder{
+			Number:      c.Number,
+			Title:       c.Subject,
+			URL:         fmt.Sprintf("%s/c/%s/+/%d", webURL, (&url.URL{Path: c.Project}).EscapedPath(), c.Number),
+			Author:      c.Owner.Username,
+			CreatedAt:   c.Created.Time,
+			Missing:     missing,
+			Discussions: c.UnresolvedCommentCount,
+			Owner:       responsiblePerson(c, reviewers),
+		})
+	}
+
+	return hoster.Repository{
+		Name: p.Name,
+		URL:  projectURL(webURL, p.Name),
+	}, reminders, nil
+}
+
+const (
+	codeReview = "Code-Review"
+	reviewer   = "REVIEWER"
+)
+
+// getReviewed returns the accounts which voted on the "Code-Review" label.
+// Any vote (+1/+2/-1/-2) counts as reviewed.
+func getReviewed(c change) []account {
+	var reviewedBy []account
+	for _, a := range c.Labels[codeReview].All {
+		if a.Value != 0 {
+			reviewedBy = append(reviewedBy, a.account)
+		}
+	}
+	return reviewedBy
+}
+
+// requestedReviewers returns the accounts in the attention set,
+// or all reviewers when nobody is in the attention set.
+// The owner of the change is never a requested reviewer.
+func requestedReviewers(c change) []account {
+	var candidates []account
+	for _, a := range c.AttentionSet {
+		candidates = append(candidates, a.Account)
+	}
+	// stable order of the attention set map
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].AccountID < candidates[j].AccountID })
+	if len(candidates) == 0 {
+		candidates = c.Reviewers[reviewer]
+	}
+
+	var requested []account
+	for _, a := range candidates {
+		if a.AccountID == c.Owner.AccountID {
+			continue
+		}
+		requested = append(requested, a)
+	}
+	return requested
+}
+
+// missingReviewers returns the chat names of the requested reviewers who haven't voted yet.
+// Reviewers without a mapping are returned with their gerrit username.
+func missingReviewers(requested, reviewedBy []account, mapping map[string]string) []string {
+	var missing []string
+
+	for _, r := range requested {
+		reviewed := false
+		for _, a := range reviewedBy {
+			if r.AccountID == a.AccountID {
+				reviewed = true
+				break
+			}
+		}
+		if reviewed {
+			continue
+		}
+
+		if name, ok := mapping[r.Username]; ok {
+			missing = append(missing, name)
+			continue
+		}
+		// missing chat name mapping, use gerrit username as fallback
+		missing = append(missing, accountName(r))
+	}
+
+	return missing
+}
+
+// responsiblePerson returns the chat name of the owner of the change
+// (fallback: gerrit name of the owner)
+func responsiblePerson(c change, reviewers map[string]string) string {
+	if owner, ok := reviewers[c.Owner.Username]; ok {
+		return owner
+	}
+	return accountName(c.Owner)
+}
+
+// accountName returns the username or the full name when no username is set.
+func accountName(a account) string {
+	if a.Username != "" {
+		return a.Username
+	}
+	return a.Name
+}
+
+// projectURL returns the web URL of the open changes of the project.
+// The name is quoted and escaped, as '+' separates the search operators.
+func projectURL(webURL, project string) string {
+	return fmt.Sprintf("%s/q/project:%s+status:open", webURL, url.QueryEscape(fmt.Sprintf("%q", project)))
+}
@@ -0,0 +1,130 @@
+package gerrit
+
+import (
+	"testing"
+
+	"github.com/sj14/review-bot/hoster"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateReminder(t *testing.T) {
+	mockedClient := &clientWrapperMock{
+		loadProjectFunc: func(project string) (projectInfo, error) {
+			return projectInfo{Name: "platform/infra"}, nil
+		},
+		loadChangesFunc: func(project string) ([]change, error) {
+			return []change{
+				{
+					Number:                 1,
+					Project:                "platform/infra",
+					Subject:                "Change0",
+					Owner:                  account{AccountID: 100, Username: "owner"},
+					UnresolvedCommentCount: 3,
+					Labels: map[string]label{codeReview: {All: []approval{
+						{account: account{AccountID: 1, Username: "user1"}, Value: 2},
+					}}},
+					Reviewers: map[string][]account{reviewer: {
+						{AccountID: 0, Username: "user0"},
+						{AccountID: 1, Username: "user1"},
+					}},
+				},
+				{Number: 2, Subject: "Change1", WorkInProgress: true},
+			}, nil
+		},
+	}
+
+	expR := []hoster.Reminder{
+		{
+			Number:      1,
+			Title:       "Change0",
+			URL:         "https://gerrit.example.com/c/platform/infra/+/1",
+			Author:      "owner",
+			Missing:     []string{"@user0"},
+			Discussions: 3,
+			Owner:       "@owner",
+		},
+	}
+
+	gotP, gotR, err := aggregate(mockedClient, "https://gerrit.example.com", "platform/infra", map[string]string{"user0": "@user0", "owner": "@owner"})
+
+	require.NoError(t, err)
+	require.Equal(t, hoster.Repository{Name: "platform/infra", URL: "https://gerrit.example.com/q/project:%22platform%2Finfra%22+status:open"}, gotP)
+	require.Equal(t, expR, gotR)
+}
+
+func TestProjectURL(t *testing.T) {
+	tests := map[string]string{
+		"platform/infra": "https://gerrit.example.com/q/project:%22platform%2Finfra%22+status:open",
+		"my project":     "https://gerrit.example.com/q/project:%22my+project%22+status:open",
+		"c++":            "https://gerrit.example.com/q/project:%22c%2B%2B%22+status:open",
+	}
+
+	for project, want := range tests {
+		require.Equal(t, want, projectURL("https://gerrit.example.com", project), project)
+	}
+}
+
+func TestGetReviewed(t *testing.T) {
+	c := change{Labels: map[string]label{
+		codeReview: {All: []approval{
+			{account: account{AccountID: 0}, Value: 2},
+			{account: account{AccountID: 1}, Value: 1},
+			{account: account{AccountID: 2}, Value: 0},
+			{account: account{AccountID: 3}, Value: -1},
+			{account: account{AccountID: 4}, Value: -2},
+		}},
+		"Verified": {All: []approval{
+			{account: account{AccountID: 5}, Value: 1},
+		}},
+	}}
+
+	got := getReviewed(c)
+
+	want := []account{{AccountID: 0}, {AccountID: 1}, {AccountID: 3}, {AccountID: 4}}
+	require.Equal(t, want, got)
+}
+
+func TestRequestedReviewers(t *testing.T) {
+	owner := account{AccountID: 100}
+
+	t.Run("attention set", func(t *testing.T) {
+		c := change{
+			Owner: owner,
+			AttentionSet: map[string]attention{
+				"2":   {Account: account{AccountID: 2}},
+				"100": {Account: owner},
+				"1":   {Account: account{AccountID: 1}},
+			},
+			Reviewers: map[string][]account{reviewer: {{AccountID: 3}}},
+		}
+		require.Equal(t, []account{{AccountID: 1}, {AccountID: 2}}, requestedReviewers(c))
+	})
+
+	t.Run("reviewers", func(t *testing.T) {
+		c := change{
+			Owner:     owner,
+			Reviewers: map[string][]account{reviewer: {{AccountID: 3}, owner}, "CC": {{AccountID: 4}}},
+		}
+		require.Equal(t, []account{{AccountID: 3}}, requestedReviewers(c))
+	})
+}
+
+func TestMissingReviewers(t *testing.T) {
+	requested := []account{
+		{AccountID: 0, Username: "user0"},
+		{AccountID: 1, Username: "user1"},
+		{AccountID: 2, Username: "user2"},
+		{AccountID: 3, Name: "User Three"},
+	}
+	reviewedBy := []account{{AccountID: 1}}
+	mapping := map[string]string{
+		"user0": "@user0",
+		"user1": "@user1",
+		// "user2": "@user2", // Test for fallback to gerrit username on missing mapping
+	}
+
+	got := missingReviewers(requested, reviewedBy, mapping)
+
+	want := []string{"@user0", "user2", "User Three"}
+	require.Equal(t, want, got)
+}
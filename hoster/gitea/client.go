@@ -0,0 +1,125 @@
+package gitea
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/sj14/review-bot/hoster"
+)
+
+const perPage = 25
+
+//go:generate go tool moq -out client_moq_test.go . clientWrapper
+type clientWrapper interface {
+	loadRepository(owner, repo string) (repository, error)
+	loadPRs(owner, repo string) ([]pullRequest, error)
+	loadReviews(owner, repo string, number int) ([]review, error)
+	loadReactions(owner, repo string, number int) ([]reaction, error)
+}
+
+type user struct {
+	Login     string `json:"login"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type repository struct {
+	Name      string `json:"name"`
+	HTMLURL   string `json:"html_url"`
+	AvatarURL string `json:"avatar_url"`
+	Owner     user   `json:"owner"`
+}
+
+type pullRequest struct {
+	Number             int       `json:"number"`
+	Title              string    `json:"title"`
+	HTMLURL            string    `json:"html_url"`
+	User               user      `json:"user"`
+	Assignee           *user     `json:"assignee"`
+	Draft              bool      `json:"draft"`
+	Comments           int       `json:"comments"`
+	RequestedReviewers []user    `json:"requested_reviewers"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+type review struct {
+	User      user   `json:"user"`
+	State     string `json:"state"`
+	Dismissed bool   `json:"dismissed"`
+}
+
+type reaction struct {
+	User    user   `json:"user"`
+	Content string `json:"content"`
+}
+
+type client struct {
+	rest *hoster.RESTClient
+}
+
+// newClient returns a new gitea client for the given API URL (e.g. https://codeberg.org/api/v1).
+func newClient(baseURL, token string) *client {
+	return &client{rest: hoster.NewRESTClient(baseURL, func(req *http.Request) {
+		if token != "" {
+			req.Header.Set("Authorization", "token "+token)
+		}
+	})}
+}
+
+// getAll loads all pages of the given API path.
+func getAll[T any](c *client, path string, query url.Values) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("limit", fmt.Sprint(perPage))
+
+	var all []T
+	for page := 1; ; page++ {
+		query.Set("page", fmt.Sprint(page))
+
+		var items []T
+		if err := c.rest.Get(path, query, &items); err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+
+		if len(items) < perPage {
+			break
+		}
+	}
+
+	return all, nil
+}
+
+func (c *client) loadRepository(owner, repo string) (repository, error) {
+	var r repository
+	if err := c.rest.Get(fmt.Sprintf("/repos/%s/%s", url.PathEscape(owner), url.PathEscape(repo)), nil, &r); err != nil {
+		return repository{}, fmt.Errorf("failed loading repo: %w", err)
+	}
+	return r, nil
+}
+
+func (c *client) loadPRs(owner, repo string) ([]pullRequest, error) {
+	prs, err := getAll[pullRequest](c, fmt.Sprintf("/repos/%s/%s/pulls", url.PathEscape(owner), url.PathEscape(repo)), url.Values{"state": {"open"}})
+	if err != nil {
+		return nil, fmt.Errorf("failed loading pull requests: %w", err)
+	}
+	return prs, nil
+}
+
+func (c *client) loadReviews(owner, repo string, number int) ([]review, error) {
+	reviews, err := getAll[review](c, fmt.Sprintf("/repos/%s/%s/pulls/%d/reviews", url.PathEscape(owner), url.PathEscape(repo), number), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading reviews of PR %v: %w", number, err)
+	}
+	return reviews, nil
+}
+
+func (c *client) loadReactions(owner, repo string, number int) ([]reaction, error) {
+	reactions, err := getAll[reaction](c, fmt.Sprintf("/repos/%s/%s/issues/%d/reactions", url.PathEscape(owner), url.PathEscape(repo), number), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading reactions of PR %v: %w", number, err)
+	}
+	return reactions, nil
+}
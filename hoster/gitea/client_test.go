@@ -0,0 +1,48 @@
+package gitea
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/repos/owner/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "token secret", r.Header.Get("Authorization"))
+		require.Equal(t, "open", r.URL.Query().Get("state"))
+
+		// first page is full, second page is the last one
+		if r.URL.Query().Get("page") == "1" {
+			fmt.Fprint(w, "[")
+			for i := 0; i < perPage; i++ {
+				if i > 0 {
+					fmt.Fprint(w, ",")
+				}
+				fmt.Fprintf(w, `{"number":%d}`, i)
+			}
+			fmt.Fprint(w, "]")
+			return
+		}
+		fmt.Fprint(w, `[{"number":100,"title":"PR","requested_reviewers":[{"login":"user0"}]}]`)
+	})
+	mux.HandleFunc("GET /api/v1/repos/owner/repo/issues/100/reactions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := newClient(srv.URL+"/api/v1/", "secret")
+
+	prs, err := c.loadPRs("owner", "repo")
+	require.NoError(t, err)
+	require.Len(t, prs, perPage+1)
+	require.Equal(t, "user0", prs[perPage].RequestedReviewers[0].Login)
+
+	_, err = c.loadReactions("owner", "repo", 100)
+	require.Error(t, err)
+}
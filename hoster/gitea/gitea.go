@@ -0,0 +1,215 @@
+package gitea
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/sj14/review-bot/hoster"
+)
+
+func init() {
+	hoster.Register("gitea", New)
+	hoster.Register("forgejo", New)
+}
+
+// host implements hoster.Hoster for Gitea and Forgejo.
+type host struct {
+	git clientWrapper
+}
+
+// New returns a Gitea/Forgejo hoster for the given host address (e.g. codeberg.org).
+func New(cfg hoster.Config) (hoster.Hoster, error) {
+	// no options supported
+	if err := cfg.Options.Check(); err != nil {
+		return nil, err
+	}
+
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("https://%s/api/v1", cfg.Host)
+	}
+
+	return &host{git: newClient(baseURL, cfg.Token)}, nil
+}
+
+// AggregateReminder will generate the reminders of the given repository (format: 'owner/repo').
+func (h *host) AggregateReminder(repo string, reviewers map[string]string) (hoster.Repository, []hoster.Reminder, error) {
+	ownerRepo := strings.SplitN(repo, "/", 2)
+	if len(ownerRepo) != 2 {
+		return hoster.Repository{}, nil, fmt.Errorf("wrong repo format %q (use 'owner/repo')", repo)
+	}
+
+	return aggregate(h.git, ownerRepo[0], ownerRepo[1], reviewers)
+}
+
+// DefaultTemplate returns the Gitea default template.
+func (h *host) DefaultTemplate() *template.Template {
+	return DefaultTemplate()
+}
+
+// NotifierTemplate returns the Gitea default template of the notifier.
+func (h *host) NotifierTemplate(notifier string) *template.Template {
+	return hoster.DefaultNotifierTemplate(notifier, teamsHowTo)
+}
+
+// helper functions for easier testability (mocked gitea client)
+func aggregate(git clientWrapper, owner, repo string, reviewers map[string]string) (hoster.Repository, []hoster.Reminder, error) {
+	repository, err := git.loadRepository(owner, repo)
+	if err != nil {
+		return hoster.Repository{}, nil, err
+	}
+
+	pullRequests, err := git.loadPRs(owner, repo)
+	if err != nil {
+		return hoster.Repository{}, nil, err
+	}
+
+	var reminders []hoster.Reminder
+
+	for _, pr := range pullRequests {
+		// don't check WIP PRs
+		if isWIP(pr) {
+			continue
+		}
+
+		reviews, err := git.loadReviews(owner, repo, pr.Number)
+		if err != nil {
+			return hoster.Repository{}, nil, err
+		}
+
+		reactions, err := git.loadReactions(owner, repo, pr.Number)
+		if err != nil {
+			return hoster.Repository{}, nil, err
+		}
+
+		reviewedBy := getReviewed(reviews, reactions)
+		missing := missingReviewers(pr.RequestedReviewers, reviewedBy, reviewers)
+
+		reminders = append(reminders, hoster.Reminder{
+			Number:      pr.Number,
+			Title:       pr.Title,
+			URL:         pr.HTMLURL,
+			Author:      pr.User.Login,
+			CreatedAt:   pr.CreatedAt,
+			Missing:     missing,
+			Discussions: pr.Comments,
+			Owner:       responsiblePerson(pr, reviewers),
+			Emojis:      aggregateEmojis(reactions),
+		})
+	}
+
+	avatarURL := repository.AvatarURL
+	if avatarURL == "" {
+		avatarURL = repository.Owner.AvatarURL
+	}
+
+	return hoster.Repository{
+		Name:      repository.Name,
+		URL:       repository.HTMLURL,
+		AvatarURL: avatarURL,
+	}, reminders, nil
+}
+
+// wipPrefixes are the default work in progress prefixes of Gitea.
+var wipPrefixes = []string{"WIP:", "[WIP]"}
+
+// isWIP returns true for drafts and PRs with a work in progress prefix.
+func isWIP(pr pullRequest) bool {
+	if pr.Draft {
+		return true
+	}
+
+	title := strings.ToUpper(strings.TrimSpace(pr.Title))
+	for _, prefix := range wipPrefixes {
+		if strings.HasPrefix(title, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	approved       = "APPROVED"
+	requestChanges = "REQUEST_CHANGES"
+	thumbsup       = "+1"
+	thumbsdown     = "-1"
+)
+
+// getReviewed returns the login of the people who have already reviewed the PR.
+// Approvals, requested changes and 👍/👎 reactions count as reviewed.
+func getReviewed(reviews []review, reactions []reaction) []string {
+	var reviewedBy []string
+
+	for _, rev := range reviews {
+		if rev.Dismissed {
+			continue
+		}
+		if rev.State == approved || rev.State == requestChanges {
+			reviewedBy = append(reviewedBy, rev.User.Login)
+		}
+	}
+
+	for _, r := range reactions {
+		if r.Content == thumbsup || r.Content == thumbsdown {
+			reviewedBy = append(reviewedBy, r.User.Login)
+		}
+	}
+
+	return reviewedBy
+}
+
+// missingReviewers returns the chat names of the requested reviewers who haven't reviewed yet.
+// Reviewers without a mapping are returned with their gitea login.
+func missingReviewers(requested []user, reviewedBy []string, mapping map[string]string) []string {
+	var missing []string
+
+	for _, r := range requested {
+		reviewed := false
+		for _, login := range reviewedBy {
+			if r.Login == login {
+				reviewed = true
+				break
+			}
+		}
+		if reviewed {
+			continue
+		}
+
+		if name, ok := mapping[r.Login]; ok {
+			missing = append(missing, name)
+			continue
+		}
+		// missing chat name mapping, use gitea login as fallback
+		missing = append(missing, r.Login)
+	}
+
+	return missing
+}
+
+// responsiblePerson returns the chat name of the assignee or author of the PR
+// (fallback: gitea login of the author)
+func responsiblePerson(pr pullRequest, reviewers map[string]string) string {
+	if pr.Assignee != nil {
+		if assignee, ok := reviewers[pr.Assignee.Login]; ok {
+			return assignee
+		}
+	}
+
+	if author, ok := reviewers[pr.User.Login]; ok {
+		return author
+	}
+
+	return pr.User.Login
+}
+
+// aggregateEmojis lists all reactions with their usage count.
+func aggregateEmojis(reactions []reaction) map[string]int {
+	var aggregate = make(map[string]int)
+
+	for _, r := range reactions {
+		aggregate[hoster.EmojiName(r.Content)]++
+	}
+
+	return aggregate
+}
@@ -0,0 +1,113 @@
+package gitea
+
+import (
+	"testing"
+
+	"github.com/sj14/review-bot/hoster"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateReminder(t *testing.T) {
+	mockedClient := &clientWrapperMock{
+		loadRepositoryFunc: func(owner, repo string) (repository, error) {
+			return repository{Name: "mocked repo", Owner: user{AvatarURL: "avatar"}}, nil
+		},
+		loadPRsFunc: func(owner, repo string) ([]pullRequest, error) {
+			return []pullRequest{
+				{Number: 1, Title: "PR0", User: user{Login: "author"}, Comments: 2, RequestedReviewers: []user{{Login: "user0"}, {Login: "user1"}}},
+				{Number: 2, Title: "WIP: PR1"},
+				{Number: 3, Title: "PR2", Draft: true},
+			}, nil
+		},
+		loadReviewsFunc: func(owner, repo string, number int) ([]review, error) {
+			return []review{{User: user{Login: "user1"}, State: approved}}, nil
+		},
+		loadReactionsFunc: func(owner, repo string, number int) ([]reaction, error) {
+			return []reaction{{User: user{Login: "user2"}, Content: "heart"}}, nil
+		},
+	}
+
+	expR := []hoster.Reminder{
+		{Number: 1, Title: "PR0", Author: "author", Missing: []string{"@user0"}, Discussions: 2, Owner: "@author", Emojis: map[string]int{"heart": 1}},
+	}
+
+	gotP, gotR, err := aggregate(mockedClient, "owner", "repo", map[string]string{"user0": "@user0", "author": "@author"})
+
+	require.NoError(t, err)
+	require.Equal(t, hoster.Repository{Name: "mocked repo", AvatarURL: "avatar"}, gotP)
+	require.Equal(t, expR, gotR)
+	require.Len(t, mockedClient.loadReviewsCalls(), 1)
+}
+
+func TestIsWIP(t *testing.T) {
+	require.True(t, isWIP(pullRequest{Draft: true}))
+	require.True(t, isWIP(pullRequest{Title: "WIP: feature"}))
+	require.True(t, isWIP(pullRequest{Title: "[wip] feature"}))
+	require.False(t, isWIP(pullRequest{Title: "feature"}))
+}
+
+func TestGetReviewed(t *testing.T) {
+	reviews := []review{
+		{User: user{Login: "user0"}, State: approved},
+		{User: user{Login: "user1"}, State: requestChanges},
+		{User: user{Login: "user2"}, State: "COMMENT"},
+		{User: user{Login: "user3"}, State: approved, Dismissed: true},
+	}
+	reactions := []reaction{
+		{User: user{Login: "user4"}, Content: thumbsup},
+		{User: user{Login: "user5"}, Content: thumbsdown},
+		{User: user{Login: "user6"}, Content: "laugh"},
+	}
+
+	got := getReviewed(reviews, reactions)
+
+	want := []string{"user0", "user1", "user4", "user5"}
+	require.Equal(t, want, got)
+}
+
+func TestMissingReviewers(t *testing.T) {
+	requested := []user{{Login: "user0"}, {Login: "user1"}, {Login: "user2"}, {Login: "user3"}}
+	reviewedBy := []string{"user1", "user2"}
+	mapping := map[string]string{
+		"user0": "@user0",
+		"user1": "@user1",
+		// "user3": "@user3", // Test for fallback to gitea login name on missing mapping
+	}
+
+	got := missingReviewers(requested, reviewedBy, mapping)
+
+	want := []string{"@user0", "user3"}
+	require.Equal(t, want, got)
+}
+
+func TestResponsiblePerson(t *testing.T) {
+	reviewers := map[string]string{"author": "@author", "assignee": "@assignee"}
+
+	t.Run("assignee", func(t *testing.T) {
+		pr := pullRequest{User: user{Login: "author"}, Assignee: &user{Login: "assignee"}}
+		require.Equal(t, "@assignee", responsiblePerson(pr, reviewers))
+	})
+
+	t.Run("author", func(t *testing.T) {
+		pr := pullRequest{User: user{Login: "author"}}
+		require.Equal(t, "@author", responsiblePerson(pr, reviewers))
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		pr := pullRequest{User: user{Login: "unknown"}}
+		require.Equal(t, "unknown", responsiblePerson(pr, reviewers))
+	})
+}
+
+func TestAggregateEmojis(t *testing.T) {
+	reactions := []reaction{
+		{Content: "+1"},
+		{Content: "+1"},
+		{Content: "-1"},
+		{Content: "hooray"},
+		{Content: "unknown"},
+	}
+
+	want := map[string]int{"thumbsup": 2, "thumbsdown": 1, "tada": 1, "unknown": 1}
+	require.Equal(t, want, aggregateEmojis(reactions))
+}
@@ -0,0 +1,23 @@
+package gitea
+
+import "text/template"
+
+// DefaultTemplate contains a project header and reminder messages.
+func DefaultTemplate() *template.Template {
+	const defaultTemplate = `
+# [{{.Repository.Name}}]({{.Repository.URL}})
+
+**How-To**: *Got reminded? Just normally review the given pull request or react with 👍/👎.*
+
+---
+
+{{range .Reminders}}
+**[{{.Title}}]({{.URL}})**
+{{if .Discussions}} {{.Discussions}} 💬 {{end}} {{range $emoji, $count := .Emojis}} {{$count}} :{{$emoji}}: {{end}} {{range .Missing}}{{.}} {{else}}You got all reviews, {{.Owner}}.{{end}}
+{{end}}
+`
+	return template.Must(template.New("default").Parse(defaultTemplate))
+}
+
+// teamsHowTo tells the reminded reviewers of the Teams template what to do.
+const teamsHowTo = "Got reminded? Just normally review the given pull request or react with 👍/👎."
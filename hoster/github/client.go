@@ -2,8 +2,12 @@ package github
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v90/github"
@@ -11,33 +15,52 @@ import (
 
 const httpTimeout = 30 * time.Second
 
+// errAnonymous is returned for requests which need authentication
+// (e.g. the GraphQL API) when no token is configured.
+var errAnonymous = errors.New("no github token configured")
+
 //go:generate go tool moq -out client_moq_test.go . clientWrapper
 type clientWrapper interface {
 	loadRepository(owner, repo string) (*github.Repository, error)
 	loadPRs(owner, repo string) ([]*github.PullRequest, error)
 	loadReviews(owner, repo string, number int) ([]*github.PullRequestReview, error)
+	loadReactions(owner, repo string, number int) ([]*github.Reaction, error)
+	loadReviewThreads(owner, repo string, number int) ([]reviewThread, error)
+	loadDismissStaleReviews(owner, repo, branch string) (bool, error)
+	loadOrgRepos(org string) ([]*github.Repository, error)
+	loadTeamRepos(org, team string) ([]*github.Repository, error)
+	loadTeamMembers(org, team string) ([]*github.User, error)
 }
 
 type client struct {
-	original *github.Client
-	ctx      context.Context
+	original      *github.Client
+	ctx           context.Context
+	authenticated bool
 }
 
 // newClient returns a new github client.
-func newClient(token string) (*client, error) {
+// An empty baseURL uses the public github.com API,
+// otherwise the GitHub Enterprise API at the given URL.
+func newClient(token, baseURL, uploadURL string) (*client, error) {
 	ctx := context.Background()
 
 	opts := []github.ClientOptionsFunc{github.WithTimeout(httpTimeout)}
 	if token != "" {
 		opts = append(opts, github.WithAuthToken(token))
 	}
+	if baseURL != "" {
+		if uploadURL == "" {
+			uploadURL = baseURL
+		}
+		opts = append(opts, github.WithEnterpriseURLs(baseURL, uploadURL))
+	}
 
 	c, err := github.NewClient(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed creating new github client: %w", err)
 	}
 
-	return &client{original: c, ctx: ctx}, nil
+	return &client{original: c, ctx: ctx, authenticated: token != ""}, nil
 }
 
 func (c *client) loadRepository(owner, repo string) (*github.Repository, error) {
@@ -96,3 +119,245 @@ func (c *client) loadReviews(owner, repo string, number int) ([]*github.PullRequ
 	}
 	return reviews, nil
 }
+
+// loadReactions returns the reactions of the PR (as an issue).
+func (c *client) loadReactions(owner, repo string, number int) ([]*github.Reaction, error) {
+	var (
+		reactions []*github.Reaction
+		opts      = &github.ListReactionOptions{ListOptions: github.ListOptions{PerPage: 25}}
+	)
+
+	for {
+		pageReactions, resp, err := c.original.Reactions.ListIssueReactions(c.ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed loading reactions: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed loading reactions, status code: %v", resp.StatusCode)
+		}
+		reactions = append(reactions, pageReactions...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return reactions, nil
+}
+
+// loadDismissStaleReviews reports whether approvals of the branch are dismissed when new commits are pushed.
+// Both, rulesets and the classic branch protection are checked.
+func (c *client) loadDismissStaleReviews(owner, repo, branch string) (bool, error) {
+	rules, resp, err := c.original.Repositories.ListRulesForBranch(c.ctx, owner, repo, branch, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return false, fmt.Errorf("failed loading rules of branch %v: %w", branch, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("failed loading rules of branch %v, status code: %v", branch, resp.StatusCode)
+	}
+	for _, rule := range rules.PullRequest {
+		if rule.Parameters.DismissStaleReviewsOnPush {
+			return true, nil
+		}
+	}
+
+	enforcement, _, err := c.original.Repositories.GetPullRequestReviewEnforcement(c.ctx, owner, repo, branch)
+	if err != nil {
+		var errResp *github.ErrorResponse
+		// the branch is not protected or the token lacks the admin permission
+		if errors.As(err, &errResp) && (errResp.Response.StatusCode == http.StatusNotFound || errResp.Response.StatusCode == http.StatusForbidden) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed loading protection of branch %v: %w", branch, err)
+	}
+	return enforcement.DismissStaleReviews, nil
+}
+
+func (c *client) loadOrgRepos(org string) ([]*github.Repository, error) {
+	var (
+		repositories []*github.Repository
+		opts         = &github.RepositoryListByOrgOptions{ListOptions: github.ListOptions{PerPage: 25}}
+	)
+
+	for {
+		pageRepos, resp, err := c.original.Repositories.ListByOrg(c.ctx, org, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed loading repos of org %v: %w", org, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed loading repos of org %v, status code: %v", org, resp.StatusCode)
+		}
+		repositories = append(repositories, pageRepos...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return repositories, nil
+}
+
+func (c *client) loadTeamRepos(org, team string) ([]*github.Repository, error) {
+	var (
+		repositories []*github.Repository
+		opts         = &github.ListOptions{PerPage: 25}
+	)
+
+	for {
+		pageRepos, resp, err := c.original.Teams.ListTeamReposBySlug(c.ctx, org, team, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed loading repos of team %v/%v: %w", org, team, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed loading repos of team %v/%v, status code: %v", org, team, resp.StatusCode)
+		}
+		repositories = append(repositories, pageRepos...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return repositories, nil
+}
+
+func (c *client) loadTeamMembers(org, team string) ([]*github.User, error) {
+	var (
+		members []*github.User
+		opts    = &github.TeamListTeamMembersOptions{ListOptions: github.ListOptions{PerPage: 25}}
+	)
+
+	for {
+		pageMembers, resp, err := c.original.Teams.ListTeamMembersBySlug(c.ctx, org, team, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed loading members of team %v/%v: %w", org, team, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed loading members of team %v/%v, status code: %v", org, team, resp.StatusCode)
+		}
+		members = append(members, pageMembers...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return members, nil
+}
+
+// graphql sends the query to the GraphQL API and decodes the data of the response into v.
+func (c *client) graphql(query string, variables map[string]interface{}, v interface{}) error {
+	u, err := graphqlURL(c.original.BaseURL())
+	if err != nil {
+		return err
+	}
+
+	body := struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}{query, variables}
+
+	req, err := c.original.NewRequest(c.ctx, http.MethodPost, u, body)
+	if err != nil {
+		return fmt.Errorf("failed creating graphql request: %w", err)
+	}
+
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if _, err := c.original.Do(req, &resp); err != nil {
+		return fmt.Errorf("failed graphql request: %w", err)
+	}
+	if len(resp.Errors) > 0 {
+		var msgs []string
+		for _, e := range resp.Errors {
+			msgs = append(msgs, e.Message)
+		}
+		return errors.New("graphql errors: " + strings.Join(msgs, "; "))
+	}
+
+	if err := json.Unmarshal(resp.Data, v); err != nil {
+		return fmt.Errorf("failed decoding graphql data: %w", err)
+	}
+	return nil
+}
+
+// graphqlURL returns the GraphQL endpoint of the given REST API URL.
+// https://api.github.com/ becomes https://api.github.com/graphql
+// and https://github.example.com/api/v3/ becomes https://github.example.com/api/graphql.
+func graphqlURL(baseURL string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("failed parsing base url: %w", err)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.Path = strings.TrimSuffix(u.Path, "/v3")
+	u.Path += "/graphql"
+	return u.String(), nil
+}
+
+// reviewThread is a conversation on the diff of a PR.
+type reviewThread struct {
+	IsResolved bool `json:"isResolved"`
+	IsOutdated bool `json:"isOutdated"`
+}
+
+const reviewThreadsQuery = `
+query($owner: String!, $repo: String!, $number: Int!, $cursor: String) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100, after: $cursor) {
+        nodes { isResolved isOutdated }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+
+// loadReviewThreads returns the review threads of the PR using the GraphQL API,
+// as the REST API doesn't provide the resolved state.
+func (c *client) loadReviewThreads(owner, repo string, number int) ([]reviewThread, error) {
+	// GraphQL is not available without authentication.
+	if !c.authenticated {
+		return nil, errAnonymous
+	}
+
+	var (
+		threads   []reviewThread
+		variables = map[string]interface{}{"owner": owner, "repo": repo, "number": number, "cursor": nil}
+	)
+
+	for {
+		var data struct {
+			Repository struct {
+				PullRequest struct {
+					ReviewThreads struct {
+						Nodes    []reviewThread `json:"nodes"`
+						PageInfo pageInfo       `json:"pageInfo"`
+					} `json:"reviewThreads"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		}
+		if err := c.graphql(reviewThreadsQuery, variables, &data); err != nil {
+			return nil, fmt.Errorf("failed loading review threads: %w", err)
+		}
+
+		page := data.Repository.PullRequest.ReviewThreads
+		threads = append(threads, page.Nodes...)
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = page.PageInfo.EndCursor
+	}
+
+	return threads, nil
+}
+
+// pageInfo is the cursor based pagination of the GraphQL API.
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
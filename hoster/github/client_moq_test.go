@@ -18,15 +18,33 @@ var _ clientWrapper = &clientWrapperMock{}
 //
 //		// make and configure a mocked clientWrapper
 //		mockedclientWrapper := &clientWrapperMock{
+//			loadDismissStaleReviewsFunc: func(owner string, repo string, branch string) (bool, error) {
+//				panic("mock out the loadDismissStaleReviews method")
+//			},
+//			loadOrgReposFunc: func(org string) ([]*github.Repository, error) {
+//				panic("mock out the loadOrgRepos method")
+//			},
 //			loadPRsFunc: func(owner string, repo string) ([]*github.PullRequest, error) {
 //				panic("mock out the loadPRs method")
 //			},
+//			loadReactionsFunc: func(owner string, repo string, number int) ([]*github.Reaction, error) {
+//				panic("mock out the loadReactions method")
+//			},
 //			loadRepositoryFunc: func(owner string, repo string) (*github.Repository, error) {
 //				panic("mock out the loadRepository method")
 //			},
+//			loadReviewThreadsFunc: func(owner string, repo string, number int) ([]reviewThread, error) {
+//				panic("mock out the loadReviewThreads method")
+//			},
 //			loadReviewsFunc: func(owner string, repo string, number int) ([]*github.PullRequestReview, error) {
 //				panic("mock out the loadReviews method")
 //			},
+//			loadTeamMembersFunc: func(org string, team string) ([]*github.User, error) {
+//				panic("mock out the loadTeamMembers method")
+//			},
+//			loadTeamReposFunc: func(org string, team string) ([]*github.Repository, error) {
+//				panic("mock out the loadTeamRepos method")
+//			},
 //		}
 //
 //		// use mockedclientWrapper in code that requires clientWrapper
@@ -34,17 +52,49 @@ var _ clientWrapper = &clientWrapperMock{}
 //
 //	}
 type clientWrapperMock struct {
+	// loadDismissStaleReviewsFunc mocks the loadDismissStaleReviews method.
+	loadDismissStaleReviewsFunc func(owner string, repo string, branch string) (bool, error)
+
+	// loadOrgReposFunc mocks the loadOrgRepos method.
+	loadOrgReposFunc func(org string) ([]*github.Repository, error)
+
 	// loadPRsFunc mocks the loadPRs method.
 	loadPRsFunc func(owner string, repo string) ([]*github.PullRequest, error)
 
+	// loadReactionsFunc mocks the loadReactions method.
+	loadReactionsFunc func(owner string, repo string, number int) ([]*github.Reaction, error)
+
 	// loadRepositoryFunc mocks the loadRepository method.
 	loadRepositoryFunc func(owner string, repo string) (*github.Repository, error)
 
+	// loadReviewThreadsFunc mocks the loadReviewThreads method.
+	loadReviewThreadsFunc func(owner string, repo string, number int) ([]reviewThread, error)
+
 	// loadReviewsFunc mocks the loadReviews method.
 	loadReviewsFunc func(owner string, repo string, number int) ([]*github.PullRequestReview, error)
 
+	// loadTeamMembersFunc mocks the loadTeamMembers method.
+	loadTeamMembersFunc func(org string, team string) ([]*github.User, error)
+
+	// loadTeamReposFunc mocks the loadTeamRepos method.
+	loadTeamReposFunc func(org string, team string) ([]*github.Repository, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// loadDismissStaleReviews holds details about calls to the loadDismissStaleReviews method.
+		loadDismissStaleReviews []struct {
+			// Owner is the owner argument value.
+			Owner string
+			// Repo is the repo argument value.
+			Repo string
+			// Branch is the branch argument value.
+			Branch string
+		}
+		// loadOrgRepos holds details about calls to the loadOrgRepos method.
+		loadOrgRepos []struct {
+			// Org is the org argument value.
+			Org string
+		}
 		// loadPRs holds details about calls to the loadPRs method.
 		loadPRs []struct {
 			// Owner is the owner argument value.
@@ -52,6 +102,15 @@ type clientWrapperMock struct {
 			// Repo is the repo argument value.
 			Repo string
 		}
+		// loadReactions holds details about calls to the loadReactions method.
+		loadReactions []struct {
+			// Owner is the owner argument value.
+			Owner string
+			// Repo is the repo argument value.
+			Repo string
+			// Number is the number argument value.
+			Number int
+		}
 		// loadRepository holds details about calls to the loadRepository method.
 		loadRepository []struct {
 			// Owner is the owner argument value.
@@ -59,6 +118,15 @@ type clientWrapperMock struct {
 			// Repo is the repo argument value.
 			Repo string
 		}
+		// loadReviewThreads holds details about calls to the loadReviewThreads method.
+		loadReviewThreads []struct {
+			// Owner is the owner argument value.
+			Owner string
+			// Repo is the repo argument value.
+			Repo string
+			// Number is the number argument value.
+			Number int
+		}
 		// loadReviews holds details about calls to the loadReviews method.
 		loadReviews []struct {
 			// Owner is the owner argument value.
@@ -68,10 +136,102 @@ type clientWrapperMock struct {
 			// Number is the number argument value.
 			Number int
 		}
+		// loadTeamMembers holds details about calls to the loadTeamMembers method.
+		loadTeamMembers []struct {
+			// Org is the org argument value.
+			Org string
+			// Team is the team argument value.
+			Team string
+		}
+		// loadTeamRepos holds details about calls to the loadTeamRepos method.
+		loadTeamRepos []struct {
+			// Org is the org argument value.
+			Org string
+			// Team is the team argument value.
+			Team string
+		}
 	}
-	lockloadPRs        sync.RWMutex
-	lockloadRepository sync.RWMutex
-	lockloadReviews    sync.RWMutex
+	lockloadDismissStaleReviews sync.RWMutex
+	lockloadOrgRepos            sync.RWMutex
+	lockloadPRs                 sync.RWMutex
+	lockloadReactions           sync.RWMutex
+	lockloadRepository          sync.RWMutex
+	lockloadReviewThreads       sync.RWMutex
+	lockloadReviews             sync.RWMutex
+	lockloadTeamMembers         sync.RWMutex
+	lockloadTeamRepos           sync.RWMutex
+}
+
+// loadDismissStaleReviews calls loadDismissStaleReviewsFunc.
+func (mock *clientWrapperMock) loadDismissStaleReviews(owner string, repo string, branch string) (bool, error) {
+	if mock.loadDismissStaleReviewsFunc == nil {
+		panic("clientWrapperMock.loadDismissStaleReviewsFunc: method is nil but clientWrapper.loadDismissStaleReviews was just called")
+	}
+	callInfo := struct {
+		Owner  string
+		Repo   string
+		Branch string
+	}{
+		Owner:  owner,
+		Repo:   repo,
+		Branch: branch,
+	}
+	mock.lockloadDismissStaleReviews.Lock()
+	mock.calls.loadDismissStaleReviews = append(mock.calls.loadDismissStaleReviews, callInfo)
+	mock.lockloadDismissStaleReviews.Unlock()
+	return mock.loadDismissStaleReviewsFunc(owner, repo, branch)
+}
+
+// loadDismissStaleReviewsCalls gets all the calls that were made to loadDismissStaleReviews.
+// Check the length with:
+//
+//	len(mockedclientWrapper.loadDismissStaleReviewsCalls())
+func (mock *clientWrapperMock) loadDismissStaleReviewsCalls() []struct {
+	Owner  string
+	Repo   string
+	Branch string
+} {
+	var calls []struct {
+		Owner  string
+		Repo   string
+		Branch string
+	}
+	mock.lockloadDismissStaleReviews.RLock()
+	calls = mock.calls.loadDismissStaleReviews
+	mock.lockloadDismissStaleReviews.RUnlock()
+	return calls
+}
+
+// loadOrgRepos calls loadOrgReposFunc.
+func (mock *clientWrapperMock) loadOrgRepos(org string) ([]*github.Repository, error) {
+	if mock.loadOrgReposFunc == nil {
+		panic("clientWrapperMock.loadOrgReposFunc: method is nil but clientWrapper.loadOrgRepos was just called")
+	}
+	callInfo := struct {
+		Org string
+	}{
+		Org: org,
+	}
+	mock.lockloadOrgRepos.Lock()
+	mock.calls.loadOrgRepos = append(mock.calls.loadOrgRepos, callInfo)
+	mock.lockloadOrgRepos.Unlock()
+	return mock.loadOrgReposFunc(org)
+}
+
+// loadOrgReposCalls gets all the calls that were made to loadOrgRepos.
+// Check the length with:
+//
+//	len(mockedclientWrapper.loadOrgReposCalls())
+func (mock *clientWrapperMock) loadOrgReposCalls() []struct {
+	Org string
+} {
+	var calls []struct {
+		Org string
+	}
+	mock.lockloadOrgRepos.RLock()
+	calls = mock.calls.loadOrgRepos
+	mock.lockloadOrgRepos.RUnlock()
+	return calls
 }
 
 // loadPRs calls loadPRsFunc.
@@ -110,6 +270,46 @@ func (mock *clientWrapperMock) loadPRsCalls() []struct {
 	return calls
 }
 
+// loadReactions calls loadReactionsFunc.
+func (mock *clientWrapperMock) loadReactions(owner string, repo string, number int) ([]*github.Reaction, error) {
+	if mock.loadReactionsFunc == nil {
+		panic("clientWrapperMock.loadReactionsFunc: method is nil but clientWrapper.loadReactions was just called")
+	}
+	callInfo := struct {
+		Owner  string
+		Repo   string
+		Number int
+	}{
+		Owner:  owner,
+		Repo:   repo,
+		Number: number,
+	}
+	mock.lockloadReactions.Lock()
+	mock.calls.loadReactions = append(mock.calls.loadReactions, callInfo)
+	mock.lockloadReactions.Unlock()
+	return mock.loadReactionsFunc(owner, repo, number)
+}
+
+// loadReactionsCalls gets all the calls that were made to loadReactions.
+// Check the length with:
+//
+//	len(mockedclientWrapper.loadReactionsCalls())
+func (mock *clientWrapperMock) loadReactionsCalls() []struct {
+	Owner  string
+	Repo   string
+	Number int
+} {
+	var calls []struct {
+		Owner  string
+		Repo   string
+		Number int
+	}
+	mock.lockloadReactions.RLock()
+	calls = mock.calls.loadReactions
+	mock.lockloadReactions.RUnlock()
+	return calls
+}
+
 // loadRepository calls loadRepositoryFunc.
 func (mock *clientWrapperMock) loadRepository(owner string, repo string) (*github.Repository, error) {
 	if mock.loadRepositoryFunc == nil {
@@ -146,6 +346,46 @@ func (mock *clientWrapperMock) loadRepositoryCalls() []struct {
 	return calls
 }
 
+// loadReviewThreads calls loadReviewThreadsFunc.
+func (mock *clientWrapperMock) loadReviewThreads(owner string, repo string, number int) ([]reviewThread, error) {
+	if mock.loadReviewThreadsFunc == nil {
+		panic("clientWrapperMock.loadReviewThreadsFunc: method is nil but clientWrapper.loadReviewThreads was just called")
+	}
+	callInfo := struct {
+		Owner  string
+		Repo   string
+		Number int
+	}{
+		Owner:  owner,
+		Repo:   repo,
+		Number: number,
+	}
+	mock.lockloadReviewThreads.Lock()
+	mock.calls.loadReviewThreads = append(mock.calls.loadReviewThreads, callInfo)
+	mock.lockloadReviewThreads.Unlock()
+	return mock.loadReviewThreadsFunc(owner, repo, number)
+}
+
+// loadReviewThreadsCalls gets all the calls that were made to loadReviewThreads.
+// Check the length with:
+//
+//	len(mockedclientWrapper.loadReviewThreadsCalls())
+func (mock *clientWrapperMock) loadReviewThreadsCalls() []struct {
+	Owner  string
+	Repo   string
+	Number int
+} {
+	var calls []struct {
+		Owner  string
+		Repo   string
+		Number int
+	}
+	mock.lockloadReviewThreads.RLock()
+	calls = mock.calls.loadReviewThreads
+	mock.lockloadReviewThreads.RUnlock()
+	return calls
+}
+
 // loadReviews calls loadReviewsFunc.
 func (mock *clientWrapperMock) loadReviews(owner string, repo string, number int) ([]*github.PullRequestReview, error) {
 	if mock.loadReviewsFunc == nil {
@@ -185,3 +425,75 @@ func (mock *clientWrapperMock) loadReviewsCalls() []struct {
 	mock.lockloadReviews.RUnlock()
 	return calls
 }
+
+// loadTeamMembers calls loadTeamMembersFunc.
+func (mock *clientWrapperMock) loadTeamMembers(org string, team string) ([]*github.User, error) {
+	if mock.loadTeamMembersFunc == nil {
+		panic("clientWrapperMock.loadTeamMembersFunc: method is nil but clientWrapper.loadTeamMembers was just called")
+	}
+	callInfo := struct {
+		Org  string
+		Team string
+	}{
+		Org:  org,
+		Team: team,
+	}
+	mock.lockloadTeamMembers.Lock()
+	mock.calls.loadTeamMembers = append(mock.calls.loadTeamMembers, callInfo)
+	mock.lockloadTeamMembers.Unlock()
+	return mock.loadTeamMembersFunc(org, team)
+}
+
+// loadTeamMembersCalls gets all the calls that were made to loadTeamMembers.
+// Check the length with:
+//
+//	len(mockedclientWrapper.loadTeamMembersCalls())
+func (mock *clientWrapperMock) loadTeamMembersCalls() []struct {
+	Org  string
+	Team string
+} {
+	var calls []struct {
+		Org  string
+		Team string
+	}
+	mock.lockloadTeamMembers.RLock()
+	calls = mock.calls.loadTeamMembers
+	mock.lockloadTeamMembers.RUnlock()
+	return calls
+}
+
+// loadTeamRepos calls loadTeamReposFunc.
+func (mock *clientWrapperMock) loadTeamRepos(org string, team string) ([]*github.Repository, error) {
+	if mock.loadTeamReposFunc == nil {
+		panic("clientWrapperMock.loadTeamReposFunc: method is nil but clientWrapper.loadTeamRepos was just called")
+	}
+	callInfo := struct {
+		Org  string
+		Team string
+	}{
+		Org:  org,
+		Team: team,
+	}
+	mock.lockloadTeamRepos.Lock()
+	mock.calls.loadTeamRepos = append(mock.calls.loadTeamRepos, callInfo)
+	mock.lockloadTeamRepos.Unlock()
+	return mock.loadTeamReposFunc(org, team)
+}
+
+// loadTeamReposCalls gets all the calls that were made to loadTeamRepos.
+// Check the length with:
+//
+//	len(mockedclientWrapper.loadTeamReposCalls())
+func (mock *clientWrapperMock) loadTeamReposCalls() []struct {
+	Org  string
+	Team string
+} {
+	var calls []struct {
+		Org  string
+		Team string
+	}
+	mock.lockloadTeamRepos.RLock()
+	calls = mock.calls.loadTeamRepos
+	mock.lockloadTeamRepos.RUnlock()
+	return calls
+}
@@ -0,0 +1,165 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sj14/review-bot/hoster"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnterpriseURL(t *testing.T) {
+	t.Run("github.com", func(t *testing.T) {
+		h, err := New(hoster.Config{Host: "github.com"})
+		require.NoError(t, err)
+		require.Equal(t, "https://api.github.com/", h.(*host).git.(*client).original.BaseURL())
+	})
+
+	t.Run("host", func(t *testing.T) {
+		h, err := New(hoster.Config{Host: "github.example.com"})
+		require.NoError(t, err)
+		require.Equal(t, "https://github.example.com/api/v3/", h.(*host).git.(*client).original.BaseURL())
+		require.Equal(t, "https://github.example.com/api/uploads/", h.(*host).git.(*client).original.UploadURL())
+	})
+
+	t.Run("base url", func(t *testing.T) {
+		h, err := New(hoster.Config{Host: "github.example.com", BaseURL: "https://api.example.com/", UploadURL: "https://uploads.example.com/"})
+		require.NoError(t, err)
+		require.Equal(t, "https://api.example.com/", h.(*host).git.(*client).original.BaseURL())
+		require.Equal(t, "https://uploads.example.com/", h.(*host).git.(*client).original.UploadURL())
+	})
+}
+
+func TestEnterpriseServer(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/owner/repo", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"name":"repo","html_url":"https://github.example.com/owner/repo"}`)
+	})
+	mux.HandleFunc("GET /api/v3/repos/owner/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"number":1,"title":"PR0","html_url":"https://github.example.com/owner/repo/pull/1","user":{"login":"author"},"requested_reviewers":[{"login":"user0"},{"login":"user1"}]},
+			{"number":2,"title":"PR1","draft":true}
+		]`)
+	})
+	mux.HandleFunc("GET /api/v3/repos/owner/repo/pulls/1/reviews", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"user":{"login":"user1"},"state":"APPROVED"}]`)
+	})
+	mux.HandleFunc("GET /api/v3/repos/owner/repo/issues/1/reactions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"user":{"login":"user2"},"content":"+1"}]`)
+	})
+	mux.HandleFunc("POST /api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		// two pages of review threads
+		if body.Variables["cursor"] == nil {
+			fmt.Fprint(w, `{"data":{"repository":{"pullRequest":{"reviewThreads":{"nodes":[{"isResolved":false},{"isResolved":true}],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}}}}`)
+			return
+		}
+		require.Equal(t, "c1", body.Variables["cursor"])
+		fmt.Fprint(w, `{"data":{"repository":{"pullRequest":{"reviewThreads":{"nodes":[{"isResolved":false,"isOutdated":true}],"pageInfo":{"hasNextPage":false}}}}}}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	h, err := New(hoster.Config{Token: "secret", BaseURL: srv.URL})
+	require.NoError(t, err)
+
+	repository, reminders, err := h.AggregateReminder("owner/repo", map[string]string{"user0": "@user0", "user1": "@user1", "author": "@author"})
+	require.NoError(t, err)
+	require.Equal(t, hoster.Repository{Name: "repo", URL: "https://github.example.com/owner/repo"}, repository)
+	require.Len(t, reminders, 1)
+	require.Equal(t, "PR0", reminders[0].Title)
+	require.Equal(t, []string{"@user0"}, reminders[0].Missing)
+	require.Equal(t, "@author", reminders[0].Owner)
+	require.Equal(t, map[string]int{"thumbsup": 1}, reminders[0].Emojis)
+	require.Equal(t, 2, reminders[0].Discussions)
+}
+
+func TestGraphQLURL(t *testing.T) {
+	tests := map[string]string{
+		"https://api.github.com/":            "https://api.github.com/graphql",
+		"https://github.example.com/api/v3/": "https://github.example.com/api/graphql",
+		"https://api.github.example.com/":    "https://api.github.example.com/graphql",
+		"https://example.com/github/api/v3/": "https://example.com/github/api/graphql",
+	}
+
+	for baseURL, want := range tests {
+		got, err := graphqlURL(baseURL)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":null,"errors":[{"message":"Could not resolve to a Repository"}]}`)
+	}))
+	defer srv.Close()
+
+	c, err := newClient("secret", srv.URL, "")
+	require.NoError(t, err)
+
+	_, err = c.loadReviewThreads("owner", "unknown", 1)
+	require.ErrorContains(t, err, "Could not resolve to a Repository")
+}
+
+func TestLoadDismissStaleReviews(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/owner/repo/rules/branches/{branch}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("branch") == "ruleset" {
+			fmt.Fprint(w, `[{"type":"pull_request","parameters":{"dismiss_stale_reviews_on_push":true}}]`)
+			return
+		}
+		fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("GET /api/v3/repos/owner/repo/branches/{branch}/protection/required_pull_request_reviews", func(w http.ResponseWriter, r *http.Request) {
+		switch r.PathValue("branch") {
+		case "protected":
+			fmt.Fprint(w, `{"dismiss_stale_reviews":true}`)
+		case "forbidden":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message":"Must have admin rights to Repository."}`)
+		case "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"Branch not protected"}`)
+		}
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c, err := newClient("secret", srv.URL, "")
+	require.NoError(t, err)
+
+	for branch, want := range map[string]bool{"ruleset": true, "protected": true, "forbidden": false, "unprotected": false} {
+		got, err := c.loadDismissStaleReviews("owner", "repo", branch)
+		require.NoError(t, err, branch)
+		require.Equal(t, want, got, branch)
+	}
+
+	_, err = c.loadDismissStaleReviews("owner", "repo", "broken")
+	require.Error(t, err)
+}
+
+func TestReviewThreadsAnonymous(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %v", r.URL)
+	}))
+	defer srv.Close()
+
+	c, err := newClient("", srv.URL, "")
+	require.NoError(t, err)
+
+	_, err = c.loadReviewThreads("owner", "repo", 1)
+	require.ErrorIs(t, err, errAnonymous)
+}
@@ -1,76 +1,386 @@
 package github
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+
 	"github.com/google/go-github/v90/github"
+	"github.com/sj14/review-bot/hoster"
+	"github.com/sj14/review-bot/options"
+)
+
+func init() {
+	hoster.Register("github", New)
+}
+
+// host implements hoster.Hoster for GitHub.
+type host struct {
+	git       clientWrapper
+	semantics reviewSemantics
+	teams     *teamCache
+}
+
+const (
+	// teamsMembers reminds the members of requested teams.
+	teamsMembers = "members"
+	// teamsMention mentions the chat handle of requested teams.
+	teamsMention = "mention"
 )
 
-type reminder struct {
-	PR          *github.PullRequest
-	Missing     []string
-	Discussions int
-	Owner       string
-	Emojis      map[string]int
+// teamCache resolves the members of teams, each team is only loaded once.
+type teamCache struct {
+	mode string // teamsMembers or teamsMention
+
+	mu      sync.Mutex
+	members map[string][]string
+}
+
+func newTeamCache(mode string) (*teamCache, error) {
+	if mode != teamsMembers && mode != teamsMention {
+		return nil, fmt.Errorf("unknown teams mode %q (use '%s' or '%s')", mode, teamsMembers, teamsMention)
+	}
+	return &teamCache{mode: mode, members: make(map[string][]string)}, nil
+}
+
+// load returns the logins of the team members.
+func (c *teamCache) load(git clientWrapper, org, team string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := org + "/" + team
+	if members, ok := c.members[key]; ok {
+		return members, nil
+	}
+
+	users, err := git.loadTeamMembers(org, team)
+	if err != nil {
+		return nil, err
+	}
+
+	members := []string{}
+	for _, u := range users {
+		members = append(members, u.GetLogin())
+	}
+	c.members[key] = members
+	return members, nil
+}
+
+// reviewSemantics configure which reviews count as reviewed.
+type reviewSemantics struct {
+	// changesRequested counts requested changes as reviewed until new commits are pushed.
+	changesRequested bool
+	// commented counts reviews with comments only as reviewed.
+	commented bool
+	// staleApprovals ignores approvals of outdated commits
+	// when the base branch dismisses stale reviews.
+	staleApprovals bool
 }
 
-// AggregateReminder will generate the reminder message.
-func AggregateReminder(token, owner, repo string, reviewers map[string]string) (*github.Repository, []reminder, error) {
-	git, err := newClient(token)
+// newReviewSemantics reads the review semantics from the options.
+func newReviewSemantics(opts options.Options) (reviewSemantics, error) {
+	var (
+		s   reviewSemantics
+		err error
+	)
+	if s.changesRequested, err = opts.Bool("changes_requested", true); err != nil {
+		return s, err
+	}
+	if s.commented, err = opts.Bool("commented", false); err != nil {
+		return s, err
+	}
+	if s.staleApprovals, err = opts.Bool("stale_approvals", false); err != nil {
+		return s, err
+	}
+	return s, nil
+}
+
+// New returns a GitHub hoster.
+// Hosts other than github.com are treated as GitHub Enterprise Server.
+// The option 'api=graphql' loads the pull requests using the GraphQL API,
+// the options 'changes_requested', 'commented' and 'stale_approvals' configure which reviews count
+// and 'teams=mention' mentions requested teams instead of their members.
+func New(cfg hoster.Config) (hoster.Hoster, error) {
+	if err := cfg.Options.Check("api", "changes_requested", "commented", "stale_approvals", "teams"); err != nil {
+		return nil, err
+	}
+
+	semantics, err := newReviewSemantics(cfg.Options)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
+	teams, err := newTeamCache(cfg.Options.String("teams", teamsMembers))
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := cfg.BaseURL
+	if baseURL == "" && cfg.Host != "" && cfg.Host != "github.com" {
+		baseURL = fmt.Sprintf("https://%s/", cfg.Host)
+	}
+
+	c, err := newClient(cfg.Token, baseURL, cfg.UploadURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch api := cfg.Options.String("api", "rest"); api {
+	case "rest":
+		return &host{git: c, semantics: semantics, teams: teams}, nil
+	case "graphql":
+		return &host{git: newGraphQLClient(c), semantics: semantics, teams: teams}, nil
+	default:
+		return nil, fmt.Errorf("unknown api %q (use 'rest' or 'graphql')", api)
+	}
+}
+
+// AggregateReminder will generate the reminders of the given repository (format: 'owner/repo').
+func (h *host) AggregateReminder(repo string, reviewers map[string]string) (hoster.Repository, []hoster.Reminder, error) {
+	ownerRepo := strings.SplitN(repo, "/", 2)
+	if len(ownerRepo) != 2 {
+		return hoster.Repository{}, nil, fmt.Errorf("wrong repo format %q (use 'owner/repo')", repo)
+	}
+
+	return aggregate(h.git, h.teams, ownerRepo[0], ownerRepo[1], reviewers, h.semantics)
+}
+
+// Discover returns the repositories of the given organisation or team (format: 'org' or 'org/team').
+// Archived repositories are skipped.
+func (h *host) Discover(group string, filter hoster.Filter) ([]string, error) {
+	return discover(h.git, group, filter)
+}
+
+// DefaultTemplate returns the GitHub default template.
+func (h *host) DefaultTemplate() *template.Template {
+	return DefaultTemplate()
+}
+
+// NotifierTemplate returns the GitHub default template of the notifier.
+func (h *host) NotifierTemplate(notifier string) *template.Template {
+	return hoster.DefaultNotifierTemplate(notifier, teamsHowTo)
+}
+
+// helper functions for easier testability (mocked github client)
+func aggregate(git clientWrapper, teams *teamCache, owner, repo string, reviewers map[string]string, semantics reviewSemantics) (hoster.Repository, []hoster.Reminder, error) {
 	repository, err := git.loadRepository(owner, repo)
 	if err != nil {
-		return nil, nil, err
+		return hoster.Repository{}, nil, err
 	}
 
 	pullRequests, err := git.loadPRs(owner, repo)
 	if err != nil {
-		return nil, nil, err
+		return hoster.Repository{}, nil, err
 	}
 
-	var reminders []reminder
+	var (
+		reminders    []hoster.Reminder
+		dismissStale = make(map[string]bool) // per base branch
+	)
 
 	for _, pr := range pullRequests {
 		if pr.GetDraft() {
 			continue
 		}
 
+		prSemantics := semantics
+		if semantics.staleApprovals {
+			branch := pr.GetBase().GetRef()
+			dismiss, ok := dismissStale[branch]
+			if !ok {
+				dismiss, err = git.loadDismissStaleReviews(owner, repo, branch)
+				if err != nil {
+					return hoster.Repository{}, nil, err
+				}
+				dismissStale[branch] = dismiss
+			}
+			prSemantics.staleApprovals = dismiss
+		}
+
 		reviews, err := git.loadReviews(owner, repo, pr.GetNumber())
 		if err != nil {
-			return nil, nil, err
+			return hoster.Repository{}, nil, err
 		}
 
-		reviewedBy := getReviewed(pr, reviews)
+		reactions, err := git.loadReactions(owner, repo, pr.GetNumber())
+		if err != nil {
+			return hoster.Repository{}, nil, err
+		}
+
+		// Fall back to the comment count when the review threads
+		// can't be loaded anonymously.
+		discussions := pr.GetComments()
+		threads, err := git.loadReviewThreads(owner, repo, pr.GetNumber())
+		switch {
+		case err == nil:
+			discussions = unresolvedThreadsCount(threads)
+		case !errors.Is(err, errAnonymous):
+			return hoster.Repository{}, nil, err
+		}
 
+		reviewedBy := getReviewed(pr, reviews, reactions, prSemantics)
 		missing := missingReviewers(pr.RequestedReviewers, reviewedBy, reviewers)
 
-		// TODO: comments not working
-		// fmt.Printf("comments: %v, review comments: %v\n", pr.GetComments(), pr.GetReviewComments())
+		for _, team := range pr.RequestedTeams {
+			members, err := teams.load(git, owner, team.GetSlug())
+			if err != nil {
+				return hoster.Repository{}, nil, err
+			}
+			for _, m := range missingTeam(owner+"/"+team.GetSlug(), members, pr.GetUser().GetLogin(), reviewedBy, reviewers, teams.mode) {
+				if !slices.Contains(missing, m) {
+					missing = append(missing, m)
+				}
+			}
+		}
 
 		owner := responsiblePerson(pr, reviewers)
 
-		// TODO: reactions/emojis
-		reminders = append(reminders, reminder{pr, missing, pr.GetComments(), owner, nil})
+		reminders = append(reminders, hoster.Reminder{
+			Number:      pr.GetNumber(),
+			Title:       pr.GetTitle(),
+			URL:         pr.GetHTMLURL(),
+			Author:      pr.GetUser().GetLogin(),
+			CreatedAt:   pr.GetCreatedAt().Time,
+			Missing:     missing,
+			Discussions: discussions,
+			Owner:       owner,
+			Emojis:      aggregateEmojis(reactions),
+		})
 	}
-	return repository, reminders, nil
+
+	return hoster.Repository{
+		Name:      repository.GetName(),
+		URL:       repository.GetHTMLURL(),
+		AvatarURL: repository.GetOwner().GetAvatarURL(),
+	}, reminders, nil
+}
+
+func discover(git clientWrapper, group string, filter hoster.Filter) ([]string, error) {
+	var (
+		repositories []*github.Repository
+		err          error
+	)
+
+	if org, team, ok := strings.Cut(group, "/"); ok {
+		repositories, err = git.loadTeamRepos(org, team)
+	} else {
+		repositories, err = git.loadOrgRepos(group)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var repos []string
+	for _, r := range repositories {
+		if r.GetArchived() {
+			continue
+		}
+		if !filter.Match(r.GetFullName(), r.Topics) {
+			continue
+		}
+		repos = append(repos, r.GetFullName())
+	}
+
+	return repos, nil
+}
+
+// unresolvedThreadsCount returns the number of unresolved review threads.
+func unresolvedThreadsCount(threads []reviewThread) int {
+	count := 0
+	for _, t := range threads {
+		if !t.IsResolved {
+			count++
+		}
+	}
+	return count
 }
 
 const (
-	approved  = "APPROVED"
-	dismissed = "DISMISSED"
+	approved         = "APPROVED"
+	changesRequested = "CHANGES_REQUESTED"
+	commented        = "COMMENTED"
+	dismissed        = "DISMISSED"
+	thumbsup         = "+1"
+	thumbsdown       = "-1"
 )
 
-func getReviewed(pr *github.PullRequest, reviews []*github.PullRequestReview) []string {
-	var reviewedBy []string
+// getReviewed returns the github login of the people who have already reviewed the PR.
+// Only the latest approval, requested changes or dismissal of each reviewer is considered,
+// besides the reviews, the reactions 👍 and 👎 count as reviewed.
+// GitHub doesn't offer a 😴 reaction to opt-out, so there is no equivalent to gitlab.
+func getReviewed(pr *github.PullRequest, reviews []*github.PullRequestReview, reactions []*github.Reaction, semantics reviewSemantics) []string {
+	var (
+		latest      = make(map[string]*github.PullRequestReview)
+		hasComments = make(map[string]bool)
+	)
+	for _, rev := range reviews {
+		login := rev.GetUser().GetLogin()
+		switch rev.GetState() {
+		case approved, changesRequested, dismissed:
+			latest[login] = rev
+		case commented:
+			hasComments[login] = true
+		}
+	}
+
+	var (
+		reviewedBy []string
+		seen       = make(map[string]bool)
+	)
+	add := func(login string) {
+		if !seen[login] {
+			seen[login] = true
+			reviewedBy = append(reviewedBy, login)
+		}
+	}
+
 	for _, rev := range reviews {
-		if rev.GetState() == approved || rev.GetState() == dismissed {
-			reviewedBy = append(reviewedBy, rev.GetUser().GetLogin())
+		login := rev.GetUser().GetLogin()
+		if rev, ok := latest[login]; ok && semantics.counts(rev, pr.GetHead().GetSHA()) {
+			add(login)
+		}
+		if semantics.commented && hasComments[login] {
+			add(login)
+		}
+	}
+	for _, r := range reactions {
+		if r.GetContent() == thumbsup || r.GetContent() == thumbsdown {
+			add(r.GetUser().GetLogin())
 		}
 	}
 	return reviewedBy
 }
 
+// counts reports whether the review counts as reviewed.
+// head is the latest commit of the PR, reviews of other commits are outdated.
+func (s reviewSemantics) counts(rev *github.PullRequestReview, head string) bool {
+	outdated := head != "" && rev.GetCommitID() != "" && rev.GetCommitID() != head
+
+	switch rev.GetState() {
+	case approved:
+		return !(s.staleApprovals && outdated)
+	case dismissed:
+		return !s.staleApprovals
+	case changesRequested:
+		return s.changesRequested && !outdated
+	}
+	return false
+}
+
+// aggregateEmojis lists all reactions with their usage count.
+func aggregateEmojis(reactions []*github.Reaction) map[string]int {
+	var aggregate = make(map[string]int)
+
+	for _, r := range reactions {
+		aggregate[hoster.EmojiName(r.GetContent())]++
+	}
+
+	return aggregate
+}
+
 func missingReviewers(requested []*github.User, reviewedBy []string, mapping map[string]string) []string {
 	var missing []string
 
@@ -104,6 +414,39 @@ func missingReviewers(requested []*github.User, reviewedBy []string, mapping map
 	return missing
 }
 
+// missingTeam returns the chat names to remind for the requested team (format: 'org/team').
+// A team counts as reviewed as soon as one of its members reviewed the PR,
+// otherwise all members (except the author) are reminded, or the team itself in mention mode.
+func missingTeam(team string, members []string, author string, reviewedBy []string, mapping map[string]string, mode string) []string {
+	for _, m := range members {
+		if slices.Contains(reviewedBy, m) {
+			return nil
+		}
+	}
+
+	if mode == teamsMention {
+		if name, ok := mapping[team]; ok {
+			return []string{name}
+		}
+		// missing chat name mapping, use the github team as fallback
+		return []string{team}
+	}
+
+	var missing []string
+	for _, m := range members {
+		if m == author {
+			continue
+		}
+		if name, ok := mapping[m]; ok {
+			missing = append(missing, name)
+			continue
+		}
+		// missing chat name mapping, use github login as fallback
+		missing = append(missing, m)
+	}
+	return missing
+}
+
 func isRequestedReviewer(reviewers []*github.User, requested *github.User) bool {
 	for _, r := range reviewers {
 		if r.GetLogin() == requested.GetLogin() {
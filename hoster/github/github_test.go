@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/google/go-github/v90/github"
+	"github.com/sj14/review-bot/hoster"
+	"github.com/sj14/review-bot/options"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,11 +28,78 @@ func TestGetReviewed(t *testing.T) {
 		{User: &github.User{Login: stringp("reviewer3")}, State: stringp(approved)}, // not requested
 	}
 
-	want := []string{"reviewer0", "reviewer1", "reviewer3"}
-	got := getReviewed(pr, reviews)
+	reactions := []*github.Reaction{
+		{User: &github.User{Login: stringp("reviewer4")}, Content: stringp(thumbsup)},
+		{User: &github.User{Login: stringp("reviewer5")}, Content: stringp(thumbsdown)},
+		{User: &github.User{Login: stringp("reviewer6")}, Content: stringp("rocket")},
+	}
+
+	want := []string{"reviewer0", "reviewer1", "reviewer3", "reviewer4", "reviewer5"}
+	got := getReviewed(pr, reviews, reactions, reviewSemantics{changesRequested: true})
 	require.Equal(t, want, got)
 }
 
+func TestGetReviewedSemantics(t *testing.T) {
+	pr := &github.PullRequest{Head: &github.PullRequestBranch{SHA: stringp("head")}}
+
+	review := func(login, state, commit string) *github.PullRequestReview {
+		return &github.PullRequestReview{User: &github.User{Login: stringp(login)}, State: stringp(state), CommitID: stringp(commit)}
+	}
+
+	reviews := []*github.PullRequestReview{
+		review("approved", approved, "head"),
+		review("approvedOld", approved, "old"),
+		review("dismissed", dismissed, "old"),
+		review("changes", changesRequested, "head"),
+		review("changesOld", changesRequested, "old"),
+		review("commented", commented, "head"),
+		// the latest approval or requested changes wins, comments don't override them
+		review("rerequested", approved, "old"),
+		review("rerequested", changesRequested, "head"),
+		review("rerequested", commented, "head"),
+	}
+
+	tests := map[string]struct {
+		semantics reviewSemantics
+		want      []string
+	}{
+		"none": {
+			want: []string{"approved", "approvedOld", "dismissed"},
+		},
+		"changes requested": {
+			semantics: reviewSemantics{changesRequested: true},
+			want:      []string{"approved", "approvedOld", "dismissed", "changes", "rerequested"},
+		},
+		"commented": {
+			semantics: reviewSemantics{commented: true},
+			want:      []string{"approved", "approvedOld", "dismissed", "commented", "rerequested"},
+		},
+		"stale approvals": {
+			semantics: reviewSemantics{staleApprovals: true},
+			want:      []string{"approved"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.want, getReviewed(pr, reviews, nil, tt.semantics))
+		})
+	}
+}
+
+func TestNewReviewSemantics(t *testing.T) {
+	s, err := newReviewSemantics(nil)
+	require.NoError(t, err)
+	require.Equal(t, reviewSemantics{changesRequested: true}, s)
+
+	s, err = newReviewSemantics(options.Options{"changes_requested": "false", "commented": "true", "stale_approvals": "true"})
+	require.NoError(t, err)
+	require.Equal(t, reviewSemantics{commented: true, staleApprovals: true}, s)
+
+	_, err = newReviewSemantics(options.Options{"commented": "sometimes"})
+	require.Error(t, err)
+}
+
 func TestMissingReviewers(t *testing.T) {
 	requested := []*github.User{
 		{Login: stringp("user0")},
@@ -97,3 +166,230 @@ func TestResponsiblePerson(t *testing.T) {
 		require.Equal(t, "unkown", got)
 	})
 }
+
+func TestAggregateReminder(t *testing.T) {
+	mockedClient := &clientWrapperMock{
+		loadRepositoryFunc: func(owner, repo string) (*github.Repository, error) {
+			return &github.Repository{Name: stringp("mocked repo")}, nil
+		},
+		loadPRsFunc: func(owner, repo string) ([]*github.PullRequest, error) {
+			return []*github.PullRequest{
+				{Number: github.Ptr(1), Title: stringp("PR0"), RequestedReviewers: []*github.User{{Login: stringp("user0")}}},
+				{Number: github.Ptr(2), Title: stringp("PR1"), Draft: github.Ptr(true)},
+			}, nil
+		},
+		loadReviewsFunc: func(owner, repo string, number int) ([]*github.PullRequestReview, error) {
+			return nil, nil
+		},
+		loadReactionsFunc: func(owner, repo string, number int) ([]*github.Reaction, error) {
+			return []*github.Reaction{{Content: stringp("hooray")}}, nil
+		},
+		loadReviewThreadsFunc: func(owner, repo string, number int) ([]reviewThread, error) {
+			return []reviewThread{{IsResolved: true}, {IsResolved: false}}, nil
+		},
+	}
+
+	expR := []hoster.Reminder{
+		{Number: 1, Title: "PR0", Missing: []string{"@user0"}, Discussions: 1, Emojis: map[string]int{"tada": 1}},
+	}
+
+	gotP, gotR, err := aggregate(mockedClient, &teamCache{mode: teamsMembers}, "owner", "repo", map[string]string{"user0": "@user0"}, reviewSemantics{})
+
+	require.NoError(t, err)
+	require.Equal(t, hoster.Repository{Name: "mocked repo"}, gotP)
+	require.Equal(t, expR, gotR)
+	require.Len(t, mockedClient.loadReviewsCalls(), 1)
+}
+
+func TestAggregateAnonymous(t *testing.T) {
+	mockedClient := &clientWrapperMock{
+		loadRepositoryFunc: func(owner, repo string) (*github.Repository, error) {
+			return &github.Repository{Name: stringp("mocked repo")}, nil
+		},
+		loadPRsFunc: func(owner, repo string) ([]*github.PullRequest, error) {
+			return []*github.PullRequest{
+				{Number: github.Ptr(1), Title: stringp("PR0"), Comments: github.Ptr(3), RequestedReviewers: []*github.User{{Login: stringp("user0")}}},
+			}, nil
+		},
+		loadReviewsFunc: func(owner, repo string, number int) ([]*github.PullRequestReview, error) {
+			return nil, nil
+		},
+		loadReactionsFunc: func(owner, repo string, number int) ([]*github.Reaction, error) {
+			return nil, nil
+		},
+		loadReviewThreadsFunc: func(owner, repo string, number int) ([]reviewThread, error) {
+			return nil, errAnonymous
+		},
+	}
+
+	expR := []hoster.Reminder{
+		{Number: 1, Title: "PR0", Missing: []string{"@user0"}, Discussions: 3, Emojis: map[string]int{}},
+	}
+
+	_, gotR, err := aggregate(mockedClient, &teamCache{mode: teamsMembers}, "owner", "repo", map[string]string{"user0": "@user0"}, reviewSemantics{})
+
+	require.NoError(t, err)
+	require.Equal(t, expR, gotR)
+}
+
+func TestAggregateStaleApprovals(t *testing.T) {
+	mockedClient := &clientWrapperMock{
+		loadRepositoryFunc: func(owner, repo string) (*github.Repository, error) {
+			return &github.Repository{}, nil
+		},
+		loadPRsFunc: func(owner, repo string) ([]*github.PullRequest, error) {
+			return []*github.PullRequest{
+				{Number: github.Ptr(1), Base: &github.PullRequestBranch{Ref: stringp("main")}, Head: &github.PullRequestBranch{SHA: stringp("new")}, RequestedReviewers: []*github.User{{Login: stringp("user0")}}},
+				{Number: github.Ptr(2), Base: &github.PullRequestBranch{Ref: stringp("main")}, Head: &github.PullRequestBranch{SHA: stringp("new")}, RequestedReviewers: []*github.User{{Login: stringp("user0")}}},
+				{Number: github.Ptr(3), Base: &github.PullRequestBranch{Ref: stringp("dev")}, Head: &github.PullRequestBranch{SHA: stringp("new")}, RequestedReviewers: []*github.User{{Login: stringp("user0")}}},
+			}, nil
+		},
+		loadReviewsFunc: func(owner, repo string, number int) ([]*github.PullRequestReview, error) {
+			return []*github.PullRequestReview{{User: &github.User{Login: stringp("user0")}, State: stringp(approved), CommitID: stringp("old")}}, nil
+		},
+		loadReactionsFunc: func(owner, repo string, number int) ([]*github.Reaction, error) {
+			return nil, nil
+		},
+		loadReviewThreadsFunc: func(owner, repo string, number int) ([]reviewThread, error) {
+			return nil, nil
+		},
+		loadDismissStaleReviewsFunc: func(owner, repo, branch string) (bool, error) {
+			return branch == "main", nil
+		},
+	}
+
+	_, reminders, err := aggregate(mockedClient, &teamCache{mode: teamsMembers}, "owner", "repo", map[string]string{"user0": "@user0"}, reviewSemantics{staleApprovals: true})
+	require.NoError(t, err)
+	require.Len(t, reminders, 3)
+	require.Equal(t, []string{"@user0"}, reminders[0].Missing)
+	require.Equal(t, []string{"@user0"}, reminders[1].Missing)
+	require.Empty(t, reminders[2].Missing)
+
+	// the protection is loaded once per base branch
+	require.Len(t, mockedClient.loadDismissStaleReviewsCalls(), 2)
+}
+
+func TestDiscover(t *testing.T) {
+	mockedClient := &clientWrapperMock{
+		loadOrgReposFunc: func(org string) ([]*github.Repository, error) {
+			return []*github.Repository{
+				{FullName: stringp("org/backend"), Topics: []string{"go"}},
+				{FullName: stringp("org/legacy"), Archived: github.Ptr(true)},
+				{FullName: stringp("org/frontend")},
+			}, nil
+		},
+		loadTeamReposFunc: func(org, team string) ([]*github.Repository, error) {
+			return []*github.Repository{{FullName: stringp("org/backend")}}, nil
+		},
+	}
+
+	t.Run("org", func(t *testing.T) {
+		got, err := discover(mockedClient, "org", hoster.Filter{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"org/backend", "org/frontend"}, got)
+	})
+
+	t.Run("team", func(t *testing.T) {
+		got, err := discover(mockedClient, "org/team", hoster.Filter{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"org/backend"}, got)
+		require.Equal(t, "team", mockedClient.loadTeamReposCalls()[0].Team)
+	})
+
+	t.Run("topic", func(t *testing.T) {
+		got, err := discover(mockedClient, "org", hoster.Filter{Topic: "go"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"org/backend"}, got)
+	})
+}
+
+func TestAggregateEmojis(t *testing.T) {
+	input := []*github.Reaction{
+		{Content: stringp(thumbsup)},
+		{Content: stringp(thumbsup)},
+		{Content: stringp(thumbsdown)},
+		{Content: stringp("laugh")},
+		{Content: stringp("unknown")},
+	}
+
+	got := aggregateEmojis(input)
+
+	want := map[string]int{"thumbsup": 2, "thumbsdown": 1, "laughing": 1, "unknown": 1}
+	require.Equal(t, want, got)
+}
+
+func TestUnresolvedThreadsCount(t *testing.T) {
+	threads := []reviewThread{
+		{IsResolved: true},
+		{IsResolved: false},
+		{IsResolved: false, IsOutdated: true},
+		{IsResolved: true, IsOutdated: true},
+	}
+
+	require.Equal(t, 2, unresolvedThreadsCount(threads))
+}
+
+func TestMissingTeam(t *testing.T) {
+	members := []string{"author", "user0", "user1"}
+	mapping := map[string]string{"user0": "@user0", "org/core": "@core-team"}
+
+	t.Run("members", func(t *testing.T) {
+		got := missingTeam("org/core", members, "author", nil, mapping, teamsMembers)
+		require.Equal(t, []string{"@user0", "user1"}, got)
+	})
+
+	t.Run("mention", func(t *testing.T) {
+		got := missingTeam("org/core", members, "author", nil, mapping, teamsMention)
+		require.Equal(t, []string{"@core-team"}, got)
+
+		got = missingTeam("org/other", members, "author", nil, mapping, teamsMention)
+		require.Equal(t, []string{"org/other"}, got)
+	})
+
+	t.Run("reviewed by member", func(t *testing.T) {
+		got := missingTeam("org/core", members, "author", []string{"user1"}, mapping, teamsMembers)
+		require.Empty(t, got)
+	})
+}
+
+func TestAggregateTeams(t *testing.T) {
+	mockedClient := &clientWrapperMock{
+		loadRepositoryFunc: func(owner, repo string) (*github.Repository, error) {
+			return &github.Repository{}, nil
+		},
+		loadPRsFunc: func(owner, repo string) ([]*github.PullRequest, error) {
+			return []*github.PullRequest{
+				{Number: github.Ptr(1), RequestedReviewers: []*github.User{{Login: stringp("user0")}}, RequestedTeams: []*github.Team{{Slug: stringp("core")}}},
+				{Number: github.Ptr(2), RequestedTeams: []*github.Team{{Slug: stringp("core")}}},
+			}, nil
+		},
+		loadReviewsFunc: func(owner, repo string, number int) ([]*github.PullRequestReview, error) {
+			return nil, nil
+		},
+		loadReactionsFunc: func(owner, repo string, number int) ([]*github.Reaction, error) {
+			return nil, nil
+		},
+		loadReviewThreadsFunc: func(owner, repo string, number int) ([]reviewThread, error) {
+			return nil, nil
+		},
+		loadTeamMembersFunc: func(org, team string) ([]*github.User, error) {
+			return []*github.User{{Login: stringp("user0")}, {Login: stringp("user1")}}, nil
+		},
+	}
+
+	teams, err := newTeamCache(teamsMembers)
+	require.NoError(t, err)
+
+	_, reminders, err := aggregate(mockedClient, teams, "owner", "repo", map[string]string{"user0": "@user0", "user1": "@user1"}, reviewSemantics{})
+	require.NoError(t, err)
+	require.Len(t, reminders, 2)
+	require.Equal(t, []string{"@user0", "@user1"}, reminders[0].Missing)
+	require.Equal(t, []string{"@user0", "@user1"}, reminders[1].Missing)
+
+	// the team is loaded once
+	require.Len(t, mockedClient.loadTeamMembersCalls(), 1)
+	require.Equal(t, "owner", mockedClient.loadTeamMembersCalls()[0].Org)
+
+	_, err = newTeamCache("everyone")
+	require.Error(t, err)
+}
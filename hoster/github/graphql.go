@@ -0,0 +1,271 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// graphqlClient loads the pull requests together with their requested reviewers,
+// reviews, reactions and review threads using the GraphQL API.
+// This needs a few queries per repository instead of several requests per pull request.
+// Repositories and discovery still use the REST API.
+type graphqlClient struct {
+	*client
+
+	mu    sync.Mutex
+	cache map[string]prDetails
+}
+
+// prDetails are the prefetched connections of a pull request.
+// A nil slice means the connection had more than one page
+// and has to be loaded separately.
+type prDetails struct {
+	reviews   []*github.PullRequestReview
+	reactions []*github.Reaction
+	threads   []reviewThread
+}
+
+func newGraphQLClient(c *client) *graphqlClient {
+	return &graphqlClient{client: c, cache: make(map[string]prDetails)}
+}
+
+func cacheKey(owner, repo string, number int) string {
+	return fmt.Sprintf("%s/%s#%d", owner, repo, number)
+}
+
+const pullRequestsQuery = `
+query($owner: String!, $repo: String!, $cursor: String) {
+  repository(owner: $owner, name: $repo) {
+    pullRequests(states: OPEN, first: 25, after: $cursor) {
+      nodes {
+        number
+        title
+        url
+        isDraft
+        createdAt
+        headRefOid
+        baseRefName
+        author { login }
+        reviewRequests(first: 100) {
+          nodes {
+            requestedReviewer {
+              __typename
+              ... on User { login }
+              ... on Team { slug name }
+            }
+          }
+        }
+        reviews(first: 100) {
+          nodes { state author { login } commit { oid } }
+          pageInfo { hasNextPage }
+        }
+        reactions(first: 100) {
+          nodes { content user { login } }
+          pageInfo { hasNextPage }
+        }
+        reviewThreads(first: 100) {
+          nodes { isResolved isOutdated }
+          pageInfo { hasNextPage }
+        }
+      }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`
+
+type gqlActor struct {
+	Login string `json:"login"`
+}
+
+type gqlPullRequest struct {
+	Number         int       `json:"number"`
+	Title          string    `json:"title"`
+	URL            string    `json:"url"`
+	IsDraft        bool      `json:"isDraft"`
+	CreatedAt      time.Time `json:"createdAt"`
+	HeadRefOid     string    `json:"headRefOid"`
+	BaseRefName    string    `json:"baseRefName"`
+	Author         *gqlActor `json:"author"`
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer *struct {
+				Typename string `json:"__typename"`
+				Login    string `json:"login"`
+				Slug     string `json:"slug"`
+				Name     string `json:"name"`
+			} `json:"requestedReviewer"`
+		} `json:"nodes"`
+	} `json:"reviewRequests"`
+	Reviews struct {
+		Nodes []struct {
+			State  string    `json:"state"`
+			Author *gqlActor `json:"author"`
+			Commit *struct {
+				Oid string `json:"oid"`
+			} `json:"commit"`
+		} `json:"nodes"`
+		PageInfo pageInfo `json:"pageInfo"`
+	} `json:"reviews"`
+	Reactions struct {
+		Nodes []struct {
+			Content string    `json:"content"`
+			User    *gqlActor `json:"user"`
+		} `json:"nodes"`
+		PageInfo pageInfo `json:"pageInfo"`
+	} `json:"reactions"`
+	ReviewThreads struct {
+		Nodes    []reviewThread `json:"nodes"`
+		PageInfo pageInfo       `json:"pageInfo"`
+	} `json:"reviewThreads"`
+}
+
+// loadPRs returns the open pull requests and caches their connections
+// for the subsequent calls of loadReviews, loadReactions and loadReviewThreads.
+func (c *graphqlClient) loadPRs(owner, repo string) ([]*github.PullRequest, error) {
+	var (
+		pullRequests []*github.PullRequest
+		variables    = map[string]interface{}{"owner": owner, "repo": repo, "cursor": nil}
+	)
+
+	for {
+		var data struct {
+			Repository struct {
+				PullRequests struct {
+					Nodes    []gqlPullRequest `json:"nodes"`
+					PageInfo pageInfo         `json:"pageInfo"`
+				} `json:"pullRequests"`
+			} `json:"repository"`
+		}
+		if err := c.graphql(pullRequestsQuery, variables, &data); err != nil {
+			return nil, fmt.Errorf("failed loading pull requests: %w", err)
+		}
+
+		page := data.Repository.PullRequests
+		c.mu.Lock()
+		for _, node := range page.Nodes {
+			pullRequests = append(pullRequests, node.pullRequest())
+			c.cache[cacheKey(owner, repo, node.Number)] = node.details()
+		}
+		c.mu.Unlock()
+
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = page.PageInfo.EndCursor
+	}
+
+	return pullRequests, nil
+}
+
+func (c *graphqlClient) cached(owner, repo string, number int) (prDetails, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	d, ok := c.cache[cacheKey(owner, repo, number)]
+	return d, ok
+}
+
+func (c *graphqlClient) loadReviews(owner, repo string, number int) ([]*github.PullRequestReview, error) {
+	if d, ok := c.cached(owner, repo, number); ok && d.reviews != nil {
+		return d.reviews, nil
+	}
+	return c.client.loadReviews(owner, repo, number)
+}
+
+func (c *graphqlClient) loadReactions(owner, repo string, number int) ([]*github.Reaction, error) {
+	if d, ok := c.cached(owner, repo, number); ok && d.reactions != nil {
+		return d.reactions, nil
+	}
+	return c.client.loadReactions(owner, repo, number)
+}
+
+func (c *graphqlClient) loadReviewThreads(owner, repo string, number int) ([]reviewThread, error) {
+	if d, ok := c.cached(owner, repo, number); ok && d.threads != nil {
+		return d.threads, nil
+	}
+	return c.client.loadReviewThreads(owner, repo, number)
+}
+
+// pullRequest converts the node into the REST representation.
+func (pr gqlPullRequest) pullRequest() *github.PullRequest {
+	result := &github.PullRequest{
+		Number:    github.Ptr(pr.Number),
+		Title:     github.Ptr(pr.Title),
+		HTMLURL:   github.Ptr(pr.URL),
+		Draft:     github.Ptr(pr.IsDraft),
+		CreatedAt: &github.Timestamp{Time: pr.CreatedAt},
+		Head:      &github.PullRequestBranch{SHA: github.Ptr(pr.HeadRefOid)},
+		Base:      &github.PullRequestBranch{Ref: github.Ptr(pr.BaseRefName)},
+	}
+	if pr.Author != nil {
+		result.User = &github.User{Login: github.Ptr(pr.Author.Login)}
+	}
+
+	for _, node := range pr.ReviewRequests.Nodes {
+		reviewer := node.RequestedReviewer
+		if reviewer == nil {
+			continue
+		}
+		switch reviewer.Typename {
+		case "User":
+			result.RequestedReviewers = append(result.RequestedReviewers, &github.User{Login: github.Ptr(reviewer.Login)})
+		case "Team":
+			result.RequestedTeams = append(result.RequestedTeams, &github.Team{Slug: github.Ptr(reviewer.Slug), Name: github.Ptr(reviewer.Name)})
+		}
+	}
+
+	return result
+}
+
+// details converts the connections of the node into their REST representation.
+// Connections with more than one page are left nil.
+func (pr gqlPullRequest) details() prDetails {
+	var d prDetails
+
+	if !pr.Reviews.PageInfo.HasNextPage {
+		d.reviews = []*github.PullRequestReview{}
+		for _, node := range pr.Reviews.Nodes {
+			review := &github.PullRequestReview{State: github.Ptr(node.State)}
+			if node.Author != nil {
+				review.User = &github.User{Login: github.Ptr(node.Author.Login)}
+			}
+			if node.Commit != nil {
+				review.CommitID = github.Ptr(node.Commit.Oid)
+			}
+			d.reviews = append(d.reviews, review)
+		}
+	}
+
+	if !pr.Reactions.PageInfo.HasNextPage {
+		d.reactions = []*github.Reaction{}
+		for _, node := range pr.Reactions.Nodes {
+			reaction := &github.Reaction{Content: github.Ptr(reactionContent(node.Content))}
+			if node.User != nil {
+				reaction.User = &github.User{Login: github.Ptr(node.User.Login)}
+			}
+			d.reactions = append(d.reactions, reaction)
+		}
+	}
+
+	if !pr.ReviewThreads.PageInfo.HasNextPage {
+		d.threads = append([]reviewThread{}, pr.ReviewThreads.Nodes...)
+	}
+
+	return d
+}
+
+// reactionContent converts the GraphQL reaction content (e.g. THUMBS_UP)
+// into the REST representation (e.g. +1).
+func reactionContent(content string) string {
+	switch content {
+	case "THUMBS_UP":
+		return "+1"
+	case "THUMBS_DOWN":
+		return "-1"
+	default:
+		return strings.ToLower(content)
+	}
+}
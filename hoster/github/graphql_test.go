@@ -0,0 +1,116 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sj14/review-bot/hoster"
+	"github.com/sj14/review-bot/options"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQLClient(t *testing.T) {
+	var prQueries, restReviews int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/owner/repo", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"repo","html_url":"https://github.example.com/owner/repo"}`)
+	})
+	mux.HandleFunc("GET /api/v3/repos/owner/repo/pulls/2/reviews", func(w http.ResponseWriter, r *http.Request) {
+		restReviews++
+		fmt.Fprint(w, `[{"user":{"login":"user0"},"state":"APPROVED"}]`)
+	})
+	mux.HandleFunc("GET /api/v3/orgs/owner/teams/core/members", func(w http.ResponseWriter, r *http.Request) {
+		// user1 reviewed on behalf of the team
+		fmt.Fprint(w, `[{"login":"user1"},{"login":"user3"}]`)
+	})
+	mux.HandleFunc("POST /api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.True(t, strings.Contains(body.Query, "pullRequests("), "unexpected query: %v", body.Query)
+		prQueries++
+
+		if body.Variables["cursor"] == nil {
+			fmt.Fprint(w, `{"data":{"repository":{"pullRequests":{"nodes":[
+				{"number":1,"title":"PR0","url":"https://github.example.com/owner/repo/pull/1","createdAt":"2024-01-02T03:04:05Z","author":{"login":"author"},
+				 "reviewRequests":{"nodes":[{"requestedReviewer":{"__typename":"User","login":"user0"}},{"requestedReviewer":{"__typename":"Team","slug":"core","name":"Core"}}]},
+				 "reviews":{"nodes":[{"state":"APPROVED","author":{"login":"user1"}}],"pageInfo":{"hasNextPage":false}},
+				 "reactions":{"nodes":[{"content":"THUMBS_UP","user":{"login":"user2"}},{"content":"HOORAY","user":{"login":"user0"}}],"pageInfo":{"hasNextPage":false}},
+				 "reviewThreads":{"nodes":[{"isResolved":false},{"isResolved":true}],"pageInfo":{"hasNextPage":false}}},
+				{"number":3,"title":"Draft","isDraft":true,"author":{"login":"author"}}
+			],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}}}`)
+			return
+		}
+		require.Equal(t, "c1", body.Variables["cursor"])
+		fmt.Fprint(w, `{"data":{"repository":{"pullRequests":{"nodes":[
+			{"number":2,"title":"PR1","url":"https://github.example.com/owner/repo/pull/2","author":null,
+			 "reviewRequests":{"nodes":[{"requestedReviewer":{"__typename":"User","login":"user0"}}]},
+			 "reviews":{"nodes":[],"pageInfo":{"hasNextPage":true}},
+			 "reactions":{"nodes":[],"pageInfo":{"hasNextPage":false}},
+			 "reviewThreads":{"nodes":[],"pageInfo":{"hasNextPage":false}}}
+		],"pageInfo":{"hasNextPage":false}}}}}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	h, err := New(hoster.Config{Token: "secret", BaseURL: srv.URL, Options: options.Options{"api": "graphql"}})
+	require.NoError(t, err)
+
+	_, reminders, err := h.AggregateReminder("owner/repo", map[string]string{"user0": "@user0", "user1": "@user1", "author": "@author"})
+	require.NoError(t, err)
+	require.Equal(t, 2, prQueries)
+	require.Equal(t, 1, restReviews, "reviews with more than one page are loaded using the REST API")
+
+	require.Len(t, reminders, 2)
+	require.Equal(t, "PR0", reminders[0].Title)
+	require.Equal(t, "https://github.example.com/owner/repo/pull/1", reminders[0].URL)
+	require.Equal(t, "author", reminders[0].Author)
+	require.Equal(t, "2024-01-02T03:04:05Z", reminders[0].CreatedAt.Format("2006-01-02T15:04:05Z"))
+	require.Equal(t, []string{"@user0"}, reminders[0].Missing)
+	require.Equal(t, "@author", reminders[0].Owner)
+	require.Equal(t, map[string]int{"thumbsup": 1, "tada": 1}, reminders[0].Emojis)
+	require.Equal(t, 1, reminders[0].Discussions)
+
+	require.Equal(t, "PR1", reminders[1].Title)
+	require.Empty(t, reminders[1].Missing)
+}
+
+func TestGraphQLClientPullRequest(t *testing.T) {
+	var node gqlPullRequest
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"number":1,"title":"PR","url":"u","isDraft":true,"author":{"login":"author"},
+		"reviewRequests":{"nodes":[{"requestedReviewer":{"__typename":"User","login":"user0"}},{"requestedReviewer":null},{"requestedReviewer":{"__typename":"Team","slug":"core","name":"Core"}}]}
+	}`), &node))
+
+	pr := node.pullRequest()
+	require.Equal(t, 1, pr.GetNumber())
+	require.True(t, pr.GetDraft())
+	require.Equal(t, "author", pr.GetUser().GetLogin())
+	require.Len(t, pr.RequestedReviewers, 1)
+	require.Equal(t, "user0", pr.RequestedReviewers[0].GetLogin())
+	require.Len(t, pr.RequestedTeams, 1)
+	require.Equal(t, "core", pr.RequestedTeams[0].GetSlug())
+}
+
+func TestReactionContent(t *testing.T) {
+	require.Equal(t, "+1", reactionContent("THUMBS_UP"))
+	require.Equal(t, "-1", reactionContent("THUMBS_DOWN"))
+	require.Equal(t, "hooray", reactionContent("HOORAY"))
+	require.Equal(t, "eyes", reactionContent("EYES"))
+}
+
+func TestUnknownAPI(t *testing.T) {
+	_, err := New(hoster.Config{Options: options.Options{"api": "soap"}})
+	require.Error(t, err)
+
+	_, err = New(hoster.Config{Options: options.Options{"unknown": "true"}})
+	require.Error(t, err)
+}
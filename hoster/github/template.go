@@ -1,44 +1,23 @@
 package github
 
-import (
-	"bytes"
-	"fmt"
-	"text/template"
-
-	"github.com/google/go-github/v90/github"
-)
+import "text/template"
 
 // DefaultTemplate contains a project header and reminder messages.
 func DefaultTemplate() *template.Template {
 	const defaultTemplate = `
-# [{{.Repository.Name}}]({{.Repository.HTMLURL}})
+# [{{.Repository.Name}}]({{.Repository.URL}})
 
-**How-To**: *Got reminded? Just normally review the given pull request.*
+**How-To**: *Got reminded? Just normally review the given pull request or react with 👍/👎.*
 
 ---
 
 {{range .Reminders}}
-**[{{.PR.Title}}]({{.PR.HTMLURL}})**
+**[{{.Title}}]({{.URL}})**
 {{if .Discussions}} {{.Discussions}} 💬 {{end}} {{range $emoji, $count := .Emojis}} {{$count}} :{{$emoji}}: {{end}} {{range .Missing}}{{.}} {{else}}You got all reviews, {{.Owner}}.{{end}}
 {{end}}
 `
 	return template.Must(template.New("default").Parse(defaultTemplate))
 }
 
-// Exec the reminder message for the given merge request.
-func ExecTemplate(template *template.Template, repository *github.Repository, reminders []reminder) (string, error) {
-	data := struct {
-		Repository *github.Repository
-		Reminders  []reminder
-	}{
-		repository,
-		reminders,
-	}
-	buffer := bytes.NewBuffer([]byte{})
-
-	if err := template.Execute(buffer, data); err != nil {
-		return "", fmt.Errorf("failed executing template: %w", err)
-	}
-
-	return buffer.String(), nil
-}
+// teamsHowTo tells the reminded reviewers of the Teams template what to do.
+const teamsHowTo = "Got reminded? Just normally review the given pull request or react with 👍/👎."
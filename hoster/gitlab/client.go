@@ -16,17 +16,21 @@ type clientWrapper interface {
 	loadMRs(repo interface{}) ([]*gitlab.BasicMergeRequest, error)
 	loadEmojis(repo interface{}, mr *gitlab.BasicMergeRequest) ([]*gitlab.AwardEmoji, error)
 	loadDiscussions(repo interface{}, mr *gitlab.BasicMergeRequest) ([]*gitlab.Discussion, error)
+	loadGroupProjects(group interface{}) ([]*gitlab.Project, error)
+	loadApprovals(repo interface{}, mr *gitlab.BasicMergeRequest) (*gitlab.MergeRequestApprovals, error)
+	loadApprovalState(repo interface{}, mr *gitlab.BasicMergeRequest) (*gitlab.MergeRequestApprovalState, error)
+	loadReviewers(repo interface{}, mr *gitlab.BasicMergeRequest) ([]*gitlab.MergeRequestReviewer, error)
 }
 
 type client struct {
 	original *gitlab.Client
 }
 
-// newClient returns a new gitlab client.
-func newClient(host, token string) (*client, error) {
+// newClient returns a new gitlab client for the given API URL.
+func newClient(baseURL, token string) (*client, error) {
 	c, err := gitlab.NewClient(
 		token,
-		gitlab.WithBaseURL(fmt.Sprintf("https://%s/api/v4", host)),
+		gitlab.WithBaseURL(baseURL),
 		gitlab.WithHTTPClient(&http.Client{Timeout: httpTimeout}),
 	)
 	if err != nil {
@@ -126,3 +130,75 @@ func (c *client) loadEmojis(repo interface{}, mr *gitlab.BasicMergeRequest) ([]*
 
 	return emojis, nil
 }
+
+// loadGroupProjects returns all active projects of the group including its subgroups.
+func (c *client) loadGroupProjects(group interface{}) ([]*gitlab.Project, error) {
+	var (
+		projects []*gitlab.Project
+		opts     = &gitlab.ListGroupProjectsOptions{
+			Archived:         gitlab.Ptr(false),
+			IncludeSubGroups: gitlab.Ptr(true),
+			ListOptions:      gitlab.ListOptions{PerPage: 25},
+		}
+	)
+
+	for {
+		pageProjects, resp, err := c.original.Groups.ListGroupProjects(group, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list projects of group %v: %w", group, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to list group projects, status code: %v", resp.StatusCode)
+		}
+		projects = append(projects, pageProjects...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return projects, nil
+}
+
+// loadApprovals returns who approved the MR and how many approvals are left.
+func (c *client) loadApprovals(repo interface{}, mr *gitlab.BasicMergeRequest) (*gitlab.MergeRequestApprovals, error) {
+	approvals, resp, err := c.original.MergeRequestApprovals.GetConfiguration(repo, mr.IID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approvals for MR %v: %w", mr.IID, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get approvals, status code: %v", resp.StatusCode)
+	}
+
+	return approvals, nil
+}
+
+// loadApprovalState returns the approval rules of the MR with their eligible approvers.
+// Without approval rules (e.g. GitLab Free), an empty state is returned.
+func (c *client) loadApprovalState(repo interface{}, mr *gitlab.BasicMergeRequest) (*gitlab.MergeRequestApprovalState, error) {
+	state, resp, err := c.original.MergeRequestApprovals.GetApprovalState(repo, mr.IID)
+	if resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+		return &gitlab.MergeRequestApprovalState{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval state for MR %v: %w", mr.IID, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get approval state, status code: %v", resp.StatusCode)
+	}
+
+	return state, nil
+}
+
+// loadReviewers returns the reviewers of the MR with their review state.
+func (c *client) loadReviewers(repo interface{}, mr *gitlab.BasicMergeRequest) ([]*gitlab.MergeRequestReviewer, error) {
+	reviewers, resp, err := c.original.MergeRequests.GetMergeRequestReviewers(repo, mr.IID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewers for MR %v: %w", mr.IID, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get reviewers, status code: %v", resp.StatusCode)
+	}
+
+	return reviewers, nil
+}
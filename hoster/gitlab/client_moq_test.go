@@ -18,18 +18,30 @@ var _ clientWrapper = &clientWrapperMock{}
 //
 //		// make and configure a mocked clientWrapper
 //		mockedclientWrapper := &clientWrapperMock{
+//			loadApprovalStateFunc: func(repo interface{}, mr *gitlab.BasicMergeRequest) (*gitlab.MergeRequestApprovalState, error) {
+//				panic("mock out the loadApprovalState method")
+//			},
+//			loadApprovalsFunc: func(repo interface{}, mr *gitlab.BasicMergeRequest) (*gitlab.MergeRequestApprovals, error) {
+//				panic("mock out the loadApprovals method")
+//			},
 //			loadDiscussionsFunc: func(repo interface{}, mr *gitlab.BasicMergeRequest) ([]*gitlab.Discussion, error) {
 //				panic("mock out the loadDiscussions method")
 //			},
 //			loadEmojisFunc: func(repo interface{}, mr *gitlab.BasicMergeRequest) ([]*gitlab.AwardEmoji, error) {
 //				panic("mock out the loadEmojis method")
 //			},
+//			loadGroupProjectsFunc: func(group interface{}) ([]*gitlab.Project, error) {
+//				panic("mock out the loadGroupProjects method")
+//			},
 //			loadMRsFunc: func(repo interface{}) ([]*gitlab.BasicMergeRequest, error) {
 //				panic("mock out the loadMRs method")
 //			},
 //			loadProjectFunc: func(repo interface{}) (gitlab.Project, error) {
 //				panic("mock out the loadProject method")
 //			},
+//			loadReviewersFunc: func(repo interface{}, mr *gitlab.BasicMergeRequest) ([]*gitlab.MergeRequestReviewer, error) {
+//				panic("mock out the loadReviewers method")
+//			},
 //		}
 //
 //		// use mockedclientWrapper in code that requires clientWrapper
@@ -37,20 +49,46 @@ var _ clientWrapper = &clientWrapperMock{}
 //
 //	}
 type clientWrapperMock struct {
+	// loadApprovalStateFunc mocks the loadApprovalState method.
+	loadApprovalStateFunc func(repo interface{}, mr *gitlab.BasicMergeRequest) (*gitlab.MergeRequestApprovalState, error)
+
+	// loadApprovalsFunc mocks the loadApprovals method.
+	loadApprovalsFunc func(repo interface{}, mr *gitlab.BasicMergeRequest) (*gitlab.MergeRequestApprovals, error)
+
 	// loadDiscussionsFunc mocks the loadDiscussions method.
 	loadDiscussionsFunc func(repo interface{}, mr *gitlab.BasicMergeRequest) ([]*gitlab.Discussion, error)
 
 	// loadEmojisFunc mocks the loadEmojis method.
 	loadEmojisFunc func(repo interface{}, mr *gitlab.BasicMergeRequest) ([]*gitlab.AwardEmoji, error)
 
+	// loadGroupProjectsFunc mocks the loadGroupProjects method.
+	loadGroupProjectsFunc func(group interface{}) ([]*gitlab.Project, error)
+
 	// loadMRsFunc mocks the loadMRs method.
 	loadMRsFunc func(repo interface{}) ([]*gitlab.BasicMergeRequest, error)
 
 	// loadProjectFunc mocks the loadProject method.
 	loadProjectFunc func(repo interface{}) (gitlab.Project, error)
 
+	// loadReviewersFunc mocks the loadReviewers method.
+	loadReviewersFunc func(repo interface{}, mr *gitlab.BasicMergeRequest) ([]*gitlab.MergeRequestReviewer, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// loadApprovalState holds details about calls to the loadApprovalState method.
+		loadApprovalState []struct {
+			// Repo is the repo argument value.
+			Repo interface{}
+			// Mr is the mr argument value.
+			Mr *gitlab.BasicMergeRequest
+		}
+		// loadApprovals holds details about calls to the loadApprovals method.
+		loadApprovals []struct {
+			// Repo is the repo argument value.
+			Repo interface{}
+			// Mr is the mr argument value.
+			Mr *gitlab.BasicMergeRequest
+		}
 		// loadDiscussions holds details about calls to the loadDiscussions method.
 		loadDiscussions []struct {
 			// Repo is the repo argument value.
@@ -65,6 +103,11 @@ type clientWrapperMock struct {
 			// Mr is the mr argument value.
 			Mr *gitlab.BasicMergeRequest
 		}
+		// loadGroupProjects holds details about calls to the loadGroupProjects method.
+		loadGroupProjects []struct {
+			// Group is the group argument value.
+			Group interface{}
+		}
 		// loadMRs holds details about calls to the loadMRs method.
 		loadMRs []struct {
 			// Repo is the repo argument value.
@@ -75,11 +118,94 @@ type clientWrapperMock struct {
 			// Repo is the repo argument value.
 			Repo interface{}
 		}
+		// loadReviewers holds details about calls to the loadReviewers method.
+		loadReviewers []struct {
+			// Repo is the repo argument value.
+			Repo interface{}
+			// Mr is the mr argument value.
+			Mr *gitlab.BasicMergeRequest
+		}
+	}
+	lockloadApprovalState sync.RWMutex
+	lockloadApprovals     sync.RWMutex
+	lockloadDiscussions   sync.RWMutex
+	lockloadEmojis        sync.RWMutex
+	lockloadGroupProjects sync.RWMutex
+	lockloadMRs           sync.RWMutex
+	lockloadProject       sync.RWMutex
+	lockloadReviewers     sync.RWMutex
+}
+
+// loadApprovalState calls loadApprovalStateFunc.
+func (mock *clientWrapperMock) loadApprovalState(repo interface{}, mr *gitlab.BasicMergeRequest) (*gitlab.MergeRequestApprovalState, error) {
+	if mock.loadApprovalStateFunc == nil {
+		panic("clientWrapperMock.loadApprovalStateFunc: method is nil but clientWrapper.loadApprovalState was just called")
+	}
+	callInfo := struct {
+		Repo interface{}
+		Mr   *gitlab.BasicMergeRequest
+	}{
+		Repo: repo,
+		Mr:   mr,
+	}
+	mock.lockloadApprovalState.Lock()
+	mock.calls.loadApprovalState = append(mock.calls.loadApprovalState, callInfo)
+	mock.lockloadApprovalState.Unlock()
+	return mock.loadApprovalStateFunc(repo, mr)
+}
+
+// loadApprovalStateCalls gets all the calls that were made to loadApprovalState.
+// Check the length with:
+//
+//	len(mockedclientWrapper.loadApprovalStateCalls())
+func (mock *clientWrapperMock) loadApprovalStateCalls() []struct {
+	Repo interface{}
+	Mr   *gitlab.BasicMergeRequest
+} {
+	var calls []struct {
+		Repo interface{}
+		Mr   *gitlab.BasicMergeRequest
+	}
+	mock.lockloadApprovalState.RLock()
+	calls = mock.calls.loadApprovalState
+	mock.lockloadApprovalState.RUnlock()
+	return calls
+}
+
+// loadApprovals calls loadApprovalsFunc.
+func (mock *clientWrapperMock) loadApprovals(repo interface{}, mr *gitlab.BasicMergeRequest) (*gitlab.MergeRequestApprovals, error) {
+	if mock.loadApprovalsFunc == nil {
+		panic("clientWrapperMock.loadApprovalsFunc: method is nil but clientWrapper.loadApprovals was just called")
+	}
+	callInfo := struct {
+		Repo interface{}
+		Mr   *gitlab.BasicMergeRequest
+	}{
+		Repo: repo,
+		Mr:   mr,
+	}
+	mock.lockloadApprovals.Lock()
+	mock.calls.loadApprovals = append(mock.calls.loadApprovals, callInfo)
+	mock.lockloadApprovals.Unlock()
+	return mock.loadApprovalsFunc(repo, mr)
+}
+
+// loadApprovalsCalls gets all the calls that were made to loadApprovals.
+// Check the length with:
+//
+//	len(mockedclientWrapper.loadApprovalsCalls())
+func (mock *clientWrapperMock) loadApprovalsCalls() []struct {
+	Repo interface{}
+	Mr   *gitlab.BasicMergeRequest
+} {
+	var calls []struct {
+		Repo interface{}
+		Mr   *gitlab.BasicMergeRequest
 	}
-	lockloadDiscussions sync.RWMutex
-	lockloadEmojis      sync.RWMutex
-	lockloadMRs         sync.RWMutex
-	lockloadProject     sync.RWMutex
+	mock.lockloadApprovals.RLock()
+	calls = mock.calls.loadApprovals
+	mock.lockloadApprovals.RUnlock()
+	return calls
 }
 
 // loadDiscussions calls loadDiscussionsFunc.
@@ -154,6 +280,38 @@ func (mock *clientWrapperMock) loadEmojisCalls() []struct {
 	return calls
 }
 
+// loadGroupProjects calls loadGroupProjectsFunc.
+func (mock *clientWrapperMock) loadGroupProjects(group interface{}) ([]*gitlab.Project, error) {
+	if mock.loadGroupProjectsFunc == nil {
+		panic("clientWrapperMock.loadGroupProjectsFunc: method is nil but clientWrapper.loadGroupProjects was just called")
+	}
+	callInfo := struct {
+		Group interface{}
+	}{
+		Group: group,
+	}
+	mock.lockloadGroupProjects.Lock()
+	mock.calls.loadGroupProjects = append(mock.calls.loadGroupProjects, callInfo)
+	mock.lockloadGroupProjects.Unlock()
+	return mock.loadGroupProjectsFunc(group)
+}
+
+// loadGroupProjectsCalls gets all the calls that were made to loadGroupProjects.
+// Check the length with:
+//
+//	len(mockedclientWrapper.loadGroupProjectsCalls())
+func (mock *clientWrapperMock) loadGroupProjectsCalls() []struct {
+	Group interface{}
+} {
+	var calls []struct {
+		Group interface{}
+	}
+	mock.lockloadGroupProjects.RLock()
+	calls = mock.calls.loadGroupProjects
+	mock.lockloadGroupProjects.RUnlock()
+	return calls
+}
+
 // loadMRs calls loadMRsFunc.
 func (mock *clientWrapperMock) loadMRs(repo interface{}) ([]*gitlab.BasicMergeRequest, error) {
 	if mock.loadMRsFunc == nil {
@@ -217,3 +375,39 @@ func (mock *clientWrapperMock) loadProjectCalls() []struct {
 	mock.lockloadProject.RUnlock()
 	return calls
 }
+
+// loadReviewers calls loadReviewersFunc.
+func (mock *clientWrapperMock) loadReviewers(repo interface{}, mr *gitlab.BasicMergeRequest) ([]*gitlab.MergeRequestReviewer, error) {
+	if mock.loadReviewersFunc == nil {
+		panic("clientWrapperMock.loadReviewersFunc: method is nil but clientWrapper.loadReviewers was just called")
+	}
+	callInfo := struct {
+		Repo interface{}
+		Mr   *gitlab.BasicMergeRequest
+	}{
+		Repo: repo,
+		Mr:   mr,
+	}
+	mock.lockloadReviewers.Lock()
+	mock.calls.loadReviewers = append(mock.calls.loadReviewers, callInfo)
+	mock.lockloadReviewers.Unlock()
+	return mock.loadReviewersFunc(repo, mr)
+}
+
+// loadReviewersCalls gets all the calls that were made to loadReviewers.
+// Check the length with:
+//
+//	len(mockedclientWrapper.loadReviewersCalls())
+func (mock *clientWrapperMock) loadReviewersCalls() []struct {
+	Repo interface{}
+	Mr   *gitlab.BasicMergeRequest
+} {
+	var calls []struct {
+		Repo interface{}
+		Mr   *gitlab.BasicMergeRequest
+	}
+	mock.lockloadReviewers.RLock()
+	calls = mock.calls.loadReviewers
+	mock.lockloadReviewers.RUnlock()
+	return calls
+}
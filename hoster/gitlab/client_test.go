@@ -0,0 +1,27 @@
+package gitlab
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+)
+
+func TestLoadApprovalStateFree(t *testing.T) {
+	for _, status := range []int{http.StatusForbidden, http.StatusNotFound} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"message":"Forbidden"}`, status)
+		}))
+
+		c, err := newClient(srv.URL, "secret")
+		require.NoError(t, err)
+
+		state, err := c.loadApprovalState(1, &gitlab.BasicMergeRequest{IID: 1})
+		require.NoError(t, err)
+		require.Empty(t, state.Rules)
+
+		srv.Close()
+	}
+}
@@ -1,37 +1,121 @@
 package gitlab
 
-import gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+import (
+	"fmt"
+	"slices"
+	"text/template"
+	"time"
+
+	"github.com/sj14/review-bot/hoster"
+	"github.com/sj14/review-bot/options"
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+)
+
+func init() {
+	hoster.Register("gitlab", New)
+}
 
-type reminder struct {
-	MR          *gitlab.BasicMergeRequest
-	Missing     []string
-	Discussions int
-	Owner       string
-	Emojis      map[string]int
+// host implements hoster.Hoster for GitLab.
+type host struct {
+	git      clientWrapper
+	settings settings
 }
 
-// AggregateReminder will generate the reminder message.
-func AggregateReminder(host, token string, repo interface{}, reviewers map[string]string) (gitlab.Project, []reminder, error) {
+// settings of the hoster, read from the options.
+type settings struct {
+	// approvals uses the approvals API instead of the emojis to detect who is missing.
+	approvals bool
+	// onlyReviewers only reminds the reviewers of the MR, if any.
+	onlyReviewers bool
+}
+
+const (
+	remindAll       = "all"
+	remindReviewers = "reviewers"
+)
+
+// newSettings reads the settings from the options.
+func newSettings(opts options.Options) (settings, error) {
+	var (
+		s   settings
+		err error
+	)
+	if s.approvals, err = opts.Bool("approvals", false); err != nil {
+		return s, err
+	}
+
+	switch remind := opts.String("remind", remindAll); remind {
+	case remindAll:
+	case remindReviewers:
+		s.onlyReviewers = true
+	default:
+		return s, fmt.Errorf("unknown remind mode %q (use '%s' or '%s')", remind, remindAll, remindReviewers)
+	}
+
+	if s.approvals && s.onlyReviewers {
+		return s, fmt.Errorf("the options 'approvals' and 'remind=%s' can't be combined", remindReviewers)
+	}
+	return s, nil
+}
+
+// New returns a GitLab hoster for the given host address (e.g. gitlab.com).
+// The option 'approvals=true' reminds the eligible approvers of the approval rules
+// and 'remind=reviewers' only the reviewers of the MR.
+func New(cfg hoster.Config) (hoster.Hoster, error) {
+	if err := cfg.Options.Check("approvals", "remind"); err != nil {
+		return nil, err
+	}
+
+	settings, err := newSettings(cfg.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("https://%s/api/v4", cfg.Host)
+	}
+
 	// setup gitlab client
-	git, err := newClient(host, token)
+	git, err := newClient(baseURL, cfg.Token)
 	if err != nil {
-		return gitlab.Project{}, nil, err
+		return nil, err
 	}
 
-	return aggregate(git, repo, reviewers)
+	return &host{git: git, settings: settings}, nil
+}
+
+// AggregateReminder will generate the reminders of the given project (id or path).
+func (h *host) AggregateReminder(repo string, reviewers map[string]string) (hoster.Repository, []hoster.Reminder, error) {
+	return aggregate(h.git, repo, reviewers, h.settings)
+}
+
+// Discover returns the projects of the given group including its subgroups.
+func (h *host) Discover(group string, filter hoster.Filter) ([]string, error) {
+	return discover(h.git, group, filter)
+}
+
+// DefaultTemplate returns the GitLab default template.
+func (h *host) DefaultTemplate() *template.Template {
+	return DefaultTemplate()
+}
+
+// NotifierTemplate returns the GitLab default template of the notifier.
+func (h *host) NotifierTemplate(notifier string) *template.Template {
+	return hoster.DefaultNotifierTemplate(notifier, teamsHowTo)
 }
 
 // helper functions for easier testability (mocked gitlab client)
-func aggregate(git clientWrapper, repo interface{}, reviewers map[string]string) (gitlab.Project, []reminder, error) {
+func aggregate(git clientWrapper, repo interface{}, reviewers map[string]string, settings settings) (hoster.Repository, []hoster.Reminder, error) {
 	project, err := git.loadProject(repo)
 	if err != nil {
-		return gitlab.Project{}, nil, err
+		return hoster.Repository{}, nil, err
 	}
 
 	// get open merge requests
 	mergeRequests, err := git.loadMRs(repo)
 	if err != nil {
-		return gitlab.Project{}, nil, err
+		return hoster.Repository{}, nil, err
 	}
 
 	// TODO: add option
@@ -39,7 +123,7 @@ func aggregate(git clientWrapper, repo interface{}, reviewers map[string]string)
 	// mergeRequests = filterOpenDiscussions(git, mergeRequests)
 
 	// will contain the reminders of all merge requests
-	var reminders []reminder
+	var reminders []hoster.Reminder
 
 	for _, mr := range mergeRequests {
 		// don't check WIP MRs
@@ -50,7 +134,7 @@ func aggregate(git clientWrapper, repo interface{}, reviewers map[string]string)
 		// load all emojis awarded to the mr
 		emojis, err := git.loadEmojis(repo, mr)
 		if err != nil {
-			return gitlab.Project{}, nil, err
+			return hoster.Repository{}, nil, err
 		}
 
 		// check who gave thumbs up/down (or "sleeping")
@@ -59,10 +143,35 @@ func aggregate(git clientWrapper, repo interface{}, reviewers map[string]string)
 		// who is missing thumbs up/down
 		missing := missingReviewers(reviewedBy, reviewers)
 
+		if settings.approvals {
+			approvals, err := git.loadApprovals(repo, mr)
+			if err != nil {
+				return hoster.Repository{}, nil, err
+			}
+
+			state, err := git.loadApprovalState(repo, mr)
+			if err != nil {
+				return hoster.Repository{}, nil, err
+			}
+
+			missing = missingApprovers(mr, approvals, state, emojis, reviewers)
+		}
+
+		var reviewStates map[string]string
+
+		if settings.onlyReviewers && len(mr.Reviewers) > 0 {
+			mrReviewers, err := git.loadReviewers(repo, mr)
+			if err != nil {
+				return hoster.Repository{}, nil, err
+			}
+
+			missing, reviewStates = missingMRReviewers(mrReviewers, reviewedBy, reviewers)
+		}
+
 		// load all discussions of the mr
 		discussions, err := git.loadDiscussions(repo, mr)
 		if err != nil {
-			return gitlab.Project{}, nil, err
+			return hoster.Repository{}, nil, err
 		}
 
 		// get the number of open discussions
@@ -74,10 +183,63 @@ func aggregate(git clientWrapper, repo interface{}, reviewers map[string]string)
 		// list each emoji with the usage count
 		emojisAggr := aggregateEmojis(emojis)
 
-		reminders = append(reminders, reminder{mr, missing, discussionsCount, owner, emojisAggr})
+		reminders = append(reminders, hoster.Reminder{
+			Number:       int(mr.IID),
+			Title:        mr.Title,
+			URL:          mr.WebURL,
+			Author:       author(mr),
+			CreatedAt:    createdAt(mr),
+			Missing:      missing,
+			Discussions:  discussionsCount,
+			Owner:        owner,
+			Emojis:       emojisAggr,
+			ReviewStates: reviewStates,
+		})
 	}
 
-	return project, reminders, nil
+	repository := hoster.Repository{
+		Name:      project.Name,
+		URL:       project.WebURL,
+		AvatarURL: project.AvatarURL,
+	}
+
+	return repository, reminders, nil
+}
+
+// author returns the gitlab username of the MR author.
+func author(mr *gitlab.BasicMergeRequest) string {
+	if mr.Author == nil {
+		return ""
+	}
+	return mr.Author.Username
+}
+
+// createdAt returns the creation time of the MR.
+func createdAt(mr *gitlab.BasicMergeRequest) time.Time {
+	if mr.CreatedAt == nil {
+		return time.Time{}
+	}
+	return *mr.CreatedAt
+}
+
+func discover(git clientWrapper, group interface{}, filter hoster.Filter) ([]string, error) {
+	projects, err := git.loadGroupProjects(group)
+	if err != nil {
+		return nil, err
+	}
+
+	var repos []string
+	for _, p := range projects {
+		if p.Archived {
+			continue
+		}
+		if !filter.Match(p.PathWithNamespace, p.Topics) {
+			continue
+		}
+		repos = append(repos, p.PathWithNamespace)
+	}
+
+	return repos, nil
 }
 
 // responsiblePerson returns the mattermost name of the assignee or author of the MR
@@ -163,6 +325,114 @@ func missingReviewers(reviewedBy []string, approvers map[string]string) []string
 	return missing
 }
 
+// missingApprovers returns the chat names of the eligible approvers of the unapproved rules.
+// The author and users who opted-out with the "sleeping" 😴 emoji are not reminded.
+// Without approval rules or for rules without eligible approvers (e.g. 'any_approver'),
+// the users of the mapping are reminded until they approved or reviewed with an emoji.
+func missingApprovers(mr *gitlab.BasicMergeRequest, approvals *gitlab.MergeRequestApprovals, state *gitlab.MergeRequestApprovalState, emojis []*gitlab.AwardEmoji, mapping map[string]string) []string {
+	var approvedBy []string
+	for _, a := range approvals.ApprovedBy {
+		if a.User != nil {
+			approvedBy = append(approvedBy, a.User.Username)
+		}
+	}
+
+	var (
+		hasRules bool
+		rules    []*gitlab.MergeRequestApprovalRule
+	)
+	for _, r := range state.Rules {
+		if r.ApprovalsRequired == 0 {
+			continue
+		}
+		hasRules = true
+		if !r.Approved {
+			rules = append(rules, r)
+		}
+	}
+
+	// no approval rules (e.g. GitLab Free), fallback to the mapping
+	if !hasRules {
+		return missingReviewers(append(getReviewed(mr, emojis), approvedBy...), mapping)
+	}
+
+	if len(rules) == 0 || (approvals.Approved && approvals.ApprovalsLeft == 0) {
+		return nil
+	}
+
+	skip := append([]string{}, approvedBy...)
+	if mr != nil && mr.Author != nil {
+		skip = append(skip, mr.Author.Username)
+	}
+	for _, emoji := range emojis {
+		if emoji.Name == sleeping {
+			skip = append(skip, emoji.User.Username)
+		}
+	}
+
+	var missing []string
+	for _, r := range rules {
+		// rules without eligible approvers (e.g. 'any_approver'), fallback to the mapping
+		if len(r.EligibleApprovers) == 0 {
+			for _, name := range missingReviewers(append(getReviewed(mr, emojis), approvedBy...), mapping) {
+				if !slices.Contains(missing, name) {
+					missing = append(missing, name)
+				}
+			}
+			continue
+		}
+
+		for _, u := range r.EligibleApprovers {
+			if slices.Contains(skip, u.Username) {
+				continue
+			}
+			skip = append(skip, u.Username)
+
+			if name, ok := mapping[u.Username]; ok {
+				missing = append(missing, name)
+				continue
+			}
+			// missing chat name mapping, use gitlab username as fallback
+			missing = append(missing, u.Username)
+		}
+	}
+
+	return missing
+}
+
+// review states of the MR reviewers which don't need a reminder.
+var reviewedStates = []string{"reviewed", "approved", "requested_changes"}
+
+// missingMRReviewers returns the chat names of the MR reviewers who haven't reviewed yet
+// and the review state by the chat name of all MR reviewers.
+// Reviewers who reacted with an emoji (see getReviewed) aren't reminded either.
+func missingMRReviewers(mrReviewers []*gitlab.MergeRequestReviewer, reviewedBy []string, mapping map[string]string) ([]string, map[string]string) {
+	var (
+		missing []string
+		states  = make(map[string]string)
+	)
+
+	for _, r := range mrReviewers {
+		if r.User == nil {
+			continue
+		}
+
+		name, ok := mapping[r.User.Username]
+		if !ok {
+			// missing chat name mapping, use gitlab username as fallback
+			name = r.User.Username
+		}
+		states[name] = r.State
+
+		if slices.Contains(reviewedStates, r.State) || slices.Contains(reviewedBy, r.User.Username) {
+			continue
+		}
+		missing = append(missing, name)
+	}
+
+	return missing, states
+}
+
 // aggregateEmojis lists all emojis with their usage count.
 func aggregateEmojis(emojis []*gitlab.AwardEmoji) map[string]int {
 	var aggregate = make(map[string]int)
@@ -1,8 +1,11 @@
 package gitlab
 
 import (
+	"regexp"
 	"testing"
 
+	"github.com/sj14/review-bot/hoster"
+	"github.com/sj14/review-bot/options"
 	"github.com/stretchr/testify/require"
 	"gitlab.com/gitlab-org/api/client-go/v2"
 )
@@ -30,15 +33,15 @@ func TestAggregateReminder(t *testing.T) {
 		},
 	}
 
-	expP := gitlab.Project{
+	expP := hoster.Repository{
 		Name: "mocked project",
 	}
 
-	expR := []reminder{
-		{MR: &gitlab.BasicMergeRequest{Title: "MR0"}, Missing: []string{"Spidy"}, Emojis: map[string]int{"thumbsup": 1}, Discussions: 1},
+	expR := []hoster.Reminder{
+		{Title: "MR0", Missing: []string{"Spidy"}, Emojis: map[string]int{"thumbsup": 1}, Discussions: 1},
 	}
 
-	gotP, gotR, err := aggregate(mockedClient, 2009901, map[string]string{"42": "Spidy"})
+	gotP, gotR, err := aggregate(mockedClient, "2009901", map[string]string{"42": "Spidy"}, settings{})
 
 	require.NoError(t, err)
 	require.Equal(t, expP, gotP)
@@ -117,3 +120,191 @@ func TestAggregateEmojis(t *testing.T) {
 	want := map[string]int{"emoji0": 3, "emoji1": 2, "emoji2": 1}
 	require.Equal(t, want, got)
 }
+
+func TestDiscover(t *testing.T) {
+	mockedClient := &clientWrapperMock{
+		loadGroupProjectsFunc: func(group interface{}) ([]*gitlab.Project, error) {
+			return []*gitlab.Project{
+				{PathWithNamespace: "group/backend", Topics: []string{"go"}},
+				{PathWithNamespace: "group/sub/backend-legacy", Topics: []string{"go"}, Archived: true},
+				{PathWithNamespace: "group/sub/frontend", Topics: []string{"js"}},
+				{PathWithNamespace: "group/sub/backend-api"},
+			}, nil
+		},
+	}
+
+	t.Run("all", func(t *testing.T) {
+		got, err := discover(mockedClient, "group", hoster.Filter{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"group/backend", "group/sub/frontend", "group/sub/backend-api"}, got)
+	})
+
+	t.Run("name", func(t *testing.T) {
+		got, err := discover(mockedClient, "group", hoster.Filter{Name: regexp.MustCompile(`backend`)})
+		require.NoError(t, err)
+		require.Equal(t, []string{"group/backend", "group/sub/backend-api"}, got)
+	})
+
+	t.Run("topic", func(t *testing.T) {
+		got, err := discover(mockedClient, "group", hoster.Filter{Topic: "go"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"group/backend"}, got)
+	})
+}
+
+func TestMissingApprovers(t *testing.T) {
+	mr := &gitlab.BasicMergeRequest{Author: &gitlab.BasicUser{Username: "author"}}
+	mapping := map[string]string{"user0": "@user0", "user1": "@user1"}
+
+	approvedBy := func(users ...string) []*gitlab.MergeRequestApproverUser {
+		var approvers []*gitlab.MergeRequestApproverUser
+		for _, u := range users {
+			approvers = append(approvers, &gitlab.MergeRequestApproverUser{User: &gitlab.BasicUser{Username: u}})
+		}
+		return approvers
+	}
+
+	eligible := func(users ...string) []*gitlab.BasicUser {
+		var approvers []*gitlab.BasicUser
+		for _, u := range users {
+			approvers = append(approvers, &gitlab.BasicUser{Username: u})
+		}
+		return approvers
+	}
+
+	t.Run("approved", func(t *testing.T) {
+		approvals := &gitlab.MergeRequestApprovals{Approved: true, ApprovedBy: approvedBy("user0")}
+		state := &gitlab.MergeRequestApprovalState{Rules: []*gitlab.MergeRequestApprovalRule{
+			{ApprovalsRequired: 1, Approved: true, EligibleApprovers: eligible("user0", "user1")},
+		}}
+		require.Empty(t, missingApprovers(mr, approvals, state, nil, mapping))
+	})
+
+	t.Run("rules", func(t *testing.T) {
+		approvals := &gitlab.MergeRequestApprovals{ApprovalsLeft: 2, ApprovedBy: approvedBy("user2")}
+		state := &gitlab.MergeRequestApprovalState{Rules: []*gitlab.MergeRequestApprovalRule{
+			// satisfied rules are skipped
+			{ApprovalsRequired: 1, Approved: true, EligibleApprovers: eligible("user5")},
+			{ApprovalsRequired: 2, EligibleApprovers: eligible("author", "user0", "user2", "user3", "user4")},
+			{ApprovalsRequired: 1, EligibleApprovers: eligible("user0", "user1")},
+		}}
+		emojis := []*gitlab.AwardEmoji{{Name: sleeping, User: gitlab.BasicUser{Username: "user4"}}}
+
+		got := missingApprovers(mr, approvals, state, emojis, mapping)
+		require.Equal(t, []string{"@user0", "user3", "@user1"}, got)
+	})
+
+	t.Run("any approver rule", func(t *testing.T) {
+		approvals := &gitlab.MergeRequestApprovals{ApprovalsLeft: 1, ApprovedBy: approvedBy("user0")}
+		state := &gitlab.MergeRequestApprovalState{Rules: []*gitlab.MergeRequestApprovalRule{
+			{RuleType: "any_approver", ApprovalsRequired: 1},
+		}}
+		got := missingApprovers(mr, approvals, state, nil, mapping)
+		require.Equal(t, []string{"@user1"}, got)
+	})
+
+	t.Run("without rules", func(t *testing.T) {
+		// GitLab Free reports MRs without rules as approved
+		approvals := &gitlab.MergeRequestApprovals{Approved: true, ApprovalsLeft: 0, ApprovedBy: approvedBy("user0")}
+		got := missingApprovers(mr, approvals, &gitlab.MergeRequestApprovalState{}, nil, mapping)
+		require.Equal(t, []string{"@user1"}, got)
+	})
+}
+
+func TestAggregateApprovals(t *testing.T) {
+	mockedClient := &clientWrapperMock{
+		loadProjectFunc: func(repo interface{}) (gitlab.Project, error) {
+			return gitlab.Project{}, nil
+		},
+		loadMRsFunc: func(repo interface{}) ([]*gitlab.BasicMergeRequest, error) {
+			return []*gitlab.BasicMergeRequest{{IID: 1, Title: "MR0"}}, nil
+		},
+		loadEmojisFunc: func(repo interface{}, mr *gitlab.BasicMergeRequest) ([]*gitlab.AwardEmoji, error) {
+			return nil, nil
+		},
+		loadDiscussionsFunc: func(repo interface{}, mr *gitlab.BasicMergeRequest) ([]*gitlab.Discussion, error) {
+			return nil, nil
+		},
+		loadApprovalsFunc: func(repo interface{}, mr *gitlab.BasicMergeRequest) (*gitlab.MergeRequestApprovals, error) {
+			return &gitlab.MergeRequestApprovals{ApprovalsLeft: 1}, nil
+		},
+		loadApprovalStateFunc: func(repo interface{}, mr *gitlab.BasicMergeRequest) (*gitlab.MergeRequestApprovalState, error) {
+			return &gitlab.MergeRequestApprovalState{Rules: []*gitlab.MergeRequestApprovalRule{
+				{ApprovalsRequired: 1, EligibleApprovers: []*gitlab.BasicUser{{Username: "42"}}},
+			}}, nil
+		},
+	}
+
+	_, reminders, err := aggregate(mockedClient, "2009901", map[string]string{"42": "Spidy", "43": "Hulk"}, settings{approvals: true})
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	require.Equal(t, []string{"Spidy"}, reminders[0].Missing)
+	require.Len(t, mockedClient.loadApprovalsCalls(), 1)
+	require.Len(t, mockedClient.loadApprovalStateCalls(), 1)
+}
+
+func TestMissingMRReviewers(t *testing.T) {
+	mrReviewers := []*gitlab.MergeRequestReviewer{
+		{User: &gitlab.BasicUser{Username: "user0"}, State: "unreviewed"},
+		{User: &gitlab.BasicUser{Username: "user1"}, State: "approved"},
+		{User: &gitlab.BasicUser{Username: "user2"}, State: "review_started"},
+		{User: &gitlab.BasicUser{Username: "user3"}, State: "unreviewed"}, // reacted with an emoji
+		{User: nil},
+	}
+
+	missing, states := missingMRReviewers(mrReviewers, []string{"user3"}, map[string]string{"user0": "@user0", "user1": "@user1"})
+	require.Equal(t, []string{"@user0", "user2"}, missing)
+	require.Equal(t, map[string]string{"@user0": "unreviewed", "@user1": "approved", "user2": "review_started", "user3": "unreviewed"}, states)
+}
+
+func TestAggregateOnlyReviewers(t *testing.T) {
+	mockedClient := &clientWrapperMock{
+		loadProjectFunc: func(repo interface{}) (gitlab.Project, error) {
+			return gitlab.Project{}, nil
+		},
+		loadMRsFunc: func(repo interface{}) ([]*gitlab.BasicMergeRequest, error) {
+			return []*gitlab.BasicMergeRequest{
+				{IID: 1, Title: "MR0", Reviewers: []*gitlab.BasicUser{{Username: "42"}}},
+				{IID: 2, Title: "MR1"},
+			}, nil
+		},
+		loadEmojisFunc: func(repo interface{}, mr *gitlab.BasicMergeRequest) ([]*gitlab.AwardEmoji, error) {
+			return nil, nil
+		},
+		loadDiscussionsFunc: func(repo interface{}, mr *gitlab.BasicMergeRequest) ([]*gitlab.Discussion, error) {
+			return nil, nil
+		},
+		loadReviewersFunc: func(repo interface{}, mr *gitlab.BasicMergeRequest) ([]*gitlab.MergeRequestReviewer, error) {
+			return []*gitlab.MergeRequestReviewer{{User: &gitlab.BasicUser{Username: "42"}, State: "unreviewed"}}, nil
+		},
+	}
+
+	_, reminders, err := aggregate(mockedClient, "2009901", map[string]string{"42": "Spidy", "43": "Hulk"}, settings{onlyReviewers: true})
+	require.NoError(t, err)
+	require.Len(t, reminders, 2)
+
+	require.Equal(t, []string{"Spidy"}, reminders[0].Missing)
+	require.Equal(t, map[string]string{"Spidy": "unreviewed"}, reminders[0].ReviewStates)
+
+	// fallback to the mapping without reviewers
+	require.ElementsMatch(t, []string{"Spidy", "Hulk"}, reminders[1].Missing)
+	require.Nil(t, reminders[1].ReviewStates)
+
+	require.Len(t, mockedClient.loadReviewersCalls(), 1)
+}
+
+func TestNewSettings(t *testing.T) {
+	s, err := newSettings(nil)
+	require.NoError(t, err)
+	require.Equal(t, settings{}, s)
+
+	s, err = newSettings(options.Options{"remind": "reviewers"})
+	require.NoError(t, err)
+	require.Equal(t, settings{onlyReviewers: true}, s)
+
+	_, err = newSettings(options.Options{"remind": "everybody"})
+	require.Error(t, err)
+
+	_, err = newSettings(options.Options{"remind": "reviewers", "approvals": "true"})
+	require.Error(t, err)
+}
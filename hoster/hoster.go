@@ -0,0 +1,244 @@
+// Package hoster contains the provider-neutral model shared by all hosters
+// (e.g. GitHub or GitLab) and the registry to look them up by name.
+package hoster
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sj14/review-bot/options"
+)
+
+// Hoster loads the open pull/merge requests of a repository and
+// aggregates them into reminders.
+type Hoster interface {
+	// AggregateReminder returns the repository and the reminders of all open requests.
+	AggregateReminder(repo string, reviewers map[string]string) (Repository, []Reminder, error)
+	// DefaultTemplate is used when no custom template is given.
+	DefaultTemplate() *template.Template
+}
+
+// NotifierTemplater is implemented by hosters with default templates for notifiers
+// which can't render the Mattermost/Slack markdown (e.g. teams).
+type NotifierTemplater interface {
+	// NotifierTemplate returns the default template of the notifier, or nil when there is none.
+	NotifierTemplate(notifier string) *template.Template
+}
+
+// DefaultNotifierTemplate returns the default template of the notifier, or nil when there is none.
+// The howTo line tells the reminded reviewers what to do on the hoster.
+// It's meant to be called from the NotifierTemplate method of the hosters.
+func DefaultNotifierTemplate(notifier, howTo string) *template.Template {
+	switch notifier {
+	case "teams":
+		return TeamsTemplate(howTo)
+	case "discord", "googlechat":
+		// the embeds/cards already contain the reminders, only custom templates add a text
+		return template.Must(template.New(notifier).Parse(""))
+	}
+	return nil
+}
+
+// TeamsTemplate contains a project header, the howTo line and reminder messages
+// in the markdown subset of Adaptive Cards, reviewers are mentioned with <at> tags.
+func TeamsTemplate(howTo string) *template.Template {
+	const teamsTemplate = `
+**[{{.Repository.Name}}]({{.Repository.URL}})**
+
+*{{howTo}}*
+{{range .Reminders}}
+- [{{.Title}}]({{.URL}}){{if .Discussions}} · {{.Discussions}} 💬{{end}} · {{range .Missing}}<at>{{.}}</at> {{else}}You got all reviews, <at>{{.Owner}}</at>.{{end}}
+{{- end}}
+`
+	funcs := template.FuncMap{"howTo": func() string { return howTo }}
+	return template.Must(template.New("teams").Funcs(funcs).Parse(teamsTemplate))
+}
+
+// Discoverer is implemented by hosters which can list the repositories
+// of a group or organisation.
+type Discoverer interface {
+	// Discover returns all repositories of the group which match the filter,
+	// in the format accepted by AggregateReminder.
+	Discover(group string, filter Filter) ([]string, error)
+}
+
+// Filter selects the discovered repositories.
+type Filter struct {
+	// Name matches the full path of the repository (e.g. 'owner/repo'), nil matches all.
+	Name *regexp.Regexp
+	// Topic the repository has to be tagged with, empty matches all.
+	Topic string
+}
+
+// Match reports whether the repository with the given path and topics passes the filter.
+func (f Filter) Match(path string, topics []string) bool {
+	if f.Name != nil && !f.Name.MatchString(path) {
+		return false
+	}
+	if f.Topic == "" {
+		return true
+	}
+	for _, t := range topics {
+		if t == f.Topic {
+			return true
+		}
+	}
+	return false
+}
+
+// Repository is the provider-neutral view of a GitHub repository or GitLab project.
+type Repository struct {
+	Name      string
+	URL       string
+	AvatarURL string
+}
+
+// Reminder contains the details of a single pull/merge request.
+type Reminder struct {
+	Number      int
+	Title       string
+	URL         string
+	Author      string
+	CreatedAt   time.Time
+	Missing     []string
+	Discussions int
+	Owner       string
+	Emojis      map[string]int
+	// ReviewStates contains the review state (e.g. approved) by the chat name of each reviewer.
+	// Only set when the hoster provides the states.
+	ReviewStates map[string]string
+}
+
+// emojiNames maps the reaction content of GitHub and Gitea to the emoji name used in chats.
+var emojiNames = map[string]string{
+	"+1":       "thumbsup",
+	"-1":       "thumbsdown",
+	"laugh":    "laughing",
+	"confused": "confused",
+	"heart":    "heart",
+	"hooray":   "tada",
+	"rocket":   "rocket",
+	"eyes":     "eyes",
+}
+
+// EmojiName returns the chat emoji name of the reaction content (e.g. 'tada' for 'hooray'),
+// unknown content is returned unchanged.
+func EmojiName(content string) string {
+	if name, ok := emojiNames[content]; ok {
+		return name
+	}
+	return content
+}
+
+// Project contains the reminders of a single repository.
+type Project struct {
+	Repository Repository
+	Reminders  []Reminder
+}
+
+// Config contains the settings required to connect to a hoster.
+type Config struct {
+	Host  string
+	Token string
+	// BaseURL of the API (e.g. https://github.example.com/api/v3/).
+	// When empty, it's derived from the host.
+	BaseURL string
+	// UploadURL of the API, only used by GitHub Enterprise.
+	// When empty, the BaseURL is used.
+	UploadURL string
+	// Options are hoster specific settings (e.g. 'api=graphql' for github).
+	Options options.Options
+}
+
+// Factory creates a new hoster from the given config.
+type Factory func(cfg Config) (Hoster, error)
+
+var factories = map[string]Factory{}
+
+// Register makes a hoster available by the given name.
+// It's meant to be called from the init function of the hoster package.
+func Register(name string, factory Factory) {
+	if _, ok := factories[name]; ok {
+		panic(fmt.Sprintf("hoster %q registered twice", name))
+	}
+	factories[name] = factory
+}
+
+// New creates the hoster registered with the given name.
+func New(name string, cfg Config) (Hoster, error) {
+	factory, ok := factories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown hoster %q (available: %v)", name, Names())
+	}
+	return factory(cfg)
+}
+
+// Names returns the names of all registered hosters.
+func Names() []string {
+	var names []string
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Aggregate collects the reminders of all given repositories.
+// Repositories without any reminders are skipped.
+func Aggregate(h Hoster, repos []string, reviewers map[string]string) ([]Project, error) {
+	var projects []Project
+
+	for _, repo := range repos {
+		repository, reminders, err := h.AggregateReminder(repo, reviewers)
+		if err != nil {
+			return nil, fmt.Errorf("failed aggregating reminders of %q: %w", repo, err)
+		}
+		if len(reminders) == 0 {
+			// prevent from sending the header only
+			continue
+		}
+		projects = append(projects, Project{Repository: repository, Reminders: reminders})
+	}
+
+	return projects, nil
+}
+
+// ExecProjects execs the template for each project
+// and joins the sections into a single reminder message.
+func ExecProjects(template *template.Template, projects []Project) (string, error) {
+	var sections []string
+
+	for _, p := range projects {
+		section, err := ExecTemplate(template, p.Repository, p.Reminders)
+		if err != nil {
+			return "", err
+		}
+		sections = append(sections, strings.TrimSpace(section))
+	}
+
+	return strings.Join(sections, "\n\n"), nil
+}
+
+// ExecTemplate execs the reminder message for the given repository and reminders.
+func ExecTemplate(template *template.Template, repository Repository, reminders []Reminder) (string, error) {
+	data := struct {
+		Repository Repository
+		Reminders  []Reminder
+	}{
+		repository,
+		reminders,
+	}
+
+	buffer := bytes.NewBuffer([]byte{})
+
+	if err := template.Execute(buffer, data); err != nil {
+		return "", fmt.Errorf("failed executing template: %w", err)
+	}
+
+	return buffer.String(), nil
+}
@@ -0,0 +1,117 @@
+package hoster
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	Register("mocked", func(cfg Config) (Hoster, error) { return nil, nil })
+
+	_, err := New("mocked", Config{})
+	require.NoError(t, err)
+
+	_, err = New("unknown", Config{})
+	require.Error(t, err)
+}
+
+func TestExecTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("test").Parse(`{{.Repository.Name}}:{{range .Reminders}} {{.Title}}{{end}}`))
+
+	got, err := ExecTemplate(tmpl, Repository{Name: "repo"}, []Reminder{{Title: "PR0"}, {Title: "PR1"}})
+	require.NoError(t, err)
+	require.Equal(t, "repo: PR0 PR1", got)
+}
+
+type mockedHoster map[string][]Reminder
+
+func (m mockedHoster) AggregateReminder(repo string, reviewers map[string]string) (Repository, []Reminder, error) {
+	reminders, ok := m[repo]
+	if !ok {
+		return Repository{}, nil, fmt.Errorf("unknown repo %q", repo)
+	}
+	return Repository{Name: repo}, reminders, nil
+}
+
+func (m mockedHoster) DefaultTemplate() *template.Template {
+	return nil
+}
+
+func TestAggregate(t *testing.T) {
+	h := mockedHoster{
+		"repo0": {{Title: "PR0"}},
+		"repo1": nil,
+		"repo2": {{Title: "PR1"}, {Title: "PR2"}},
+	}
+
+	got, err := Aggregate(h, []string{"repo0", "repo1", "repo2"}, nil)
+	require.NoError(t, err)
+
+	want := []Project{
+		{Repository: Repository{Name: "repo0"}, Reminders: []Reminder{{Title: "PR0"}}},
+		{Repository: Repository{Name: "repo2"}, Reminders: []Reminder{{Title: "PR1"}, {Title: "PR2"}}},
+	}
+	require.Equal(t, want, got)
+
+	_, err = Aggregate(h, []string{"repo0", "unknown"}, nil)
+	require.Error(t, err)
+}
+
+func TestExecProjects(t *testing.T) {
+	tmpl := template.Must(template.New("test").Parse("\n# {{.Repository.Name}}\n{{range .Reminders}}{{.Title}}\n{{end}}"))
+
+	projects := []Project{
+		{Repository: Repository{Name: "repo0"}, Reminders: []Reminder{{Title: "PR0"}}},
+		{Repository: Repository{Name: "repo1"}, Reminders: []Reminder{{Title: "PR1"}, {Title: "PR2"}}},
+	}
+
+	got, err := ExecProjects(tmpl, projects)
+	require.NoError(t, err)
+	require.Equal(t, "# repo0\nPR0\n\n# repo1\nPR1\nPR2", got)
+}
+
+func TestFilterMatch(t *testing.T) {
+	require.True(t, Filter{}.Match("owner/repo", nil))
+	require.True(t, Filter{Name: regexp.MustCompile(`^owner/`)}.Match("owner/repo", nil))
+	require.False(t, Filter{Name: regexp.MustCompile(`^other/`)}.Match("owner/repo", nil))
+	require.True(t, Filter{Topic: "go"}.Match("owner/repo", []string{"cli", "go"}))
+	require.False(t, Filter{Topic: "go"}.Match("owner/repo", []string{"cli"}))
+	require.False(t, Filter{Name: regexp.MustCompile(`^owner/`), Topic: "go"}.Match("owner/repo", nil))
+}
+
+func TestTeamsTemplate(t *testing.T) {
+	got, err := ExecTemplate(TeamsTemplate("Got reminded? Just normally review the given pull request or react with 👍/👎."), Repository{Name: "repo", URL: "https://github.com/owner/repo"}, []Reminder{
+		{Title: "PR0", URL: "https://github.com/owner/repo/pull/0", Discussions: 2, Missing: []string{"hulk@example.com", "groot@example.com"}},
+		{Title: "PR1", URL: "https://github.com/owner/repo/pull/1", Owner: "groot@example.com"},
+	})
+	require.NoError(t, err)
+
+	want := `
+**[repo](https://github.com/owner/repo)**
+
+*Got reminded? Just normally review the given pull request or react with 👍/👎.*
+
+- [PR0](https://github.com/owner/repo/pull/0) · 2 💬 · <at>hulk@example.com</at> <at>groot@example.com</at> 
+- [PR1](https://github.com/owner/repo/pull/1) · You got all reviews, <at>groot@example.com</at>.
+`
+	require.Equal(t, want, got)
+
+	require.NotNil(t, DefaultNotifierTemplate("teams", "How-To"))
+	require.Nil(t, DefaultNotifierTemplate("slack", "How-To"))
+
+	text, err := ExecTemplate(DefaultNotifierTemplate("googlechat", "How-To"), Repository{Name: "repo"}, nil)
+	require.NoError(t, err)
+	require.Empty(t, text)
+}
+
+func TestEmojiName(t *testing.T) {
+	require.Equal(t, "thumbsup", EmojiName("+1"))
+	require.Equal(t, "thumbsdown", EmojiName("-1"))
+	require.Equal(t, "laughing", EmojiName("laugh"))
+	require.Equal(t, "tada", EmojiName("hooray"))
+	require.Equal(t, "unknown", EmojiName("unknown"))
+}
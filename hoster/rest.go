@@ -0,0 +1,83 @@
+package hoster
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const restTimeout = 30 * time.Second
+
+// RESTClient sends GET requests to a JSON REST API.
+// It's shared by the hosters without a client library (e.g. Gitea or Bitbucket).
+type RESTClient struct {
+	baseURL string
+	auth    func(req *http.Request)
+	http    *http.Client
+
+	// Prefix of the response body which is skipped before decoding
+	// (e.g. the XSSI protection of Gerrit).
+	Prefix string
+}
+
+// NewRESTClient returns a client for the API at the base URL.
+// The auth function adds the credentials to each request, it's skipped when nil.
+func NewRESTClient(baseURL string, auth func(req *http.Request)) *RESTClient {
+	return &RESTClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		auth:    auth,
+		http:    &http.Client{Timeout: restTimeout},
+	}
+}
+
+// Get decodes the response of the given API path into v.
+func (c *RESTClient) Get(path string, query url.Values, v interface{}) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.auth != nil {
+		c.auth(req)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("failed to close %v response body: %v\n", req.URL.Host, err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status code: %v; body: %v", resp.StatusCode, string(body))
+	}
+
+	body := bufio.NewReader(resp.Body)
+	if c.Prefix != "" {
+		if p, _ := body.Peek(len(c.Prefix)); bytes.Equal(p, []byte(c.Prefix)) {
+			_, _ = body.Discard(len(c.Prefix))
+		}
+	}
+
+	if err := json.NewDecoder(body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
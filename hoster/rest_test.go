@@ -0,0 +1,46 @@
+package hoster
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRESTClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Accept"))
+		require.Equal(t, "token secret", r.Header.Get("Authorization"))
+
+		switch r.URL.Path {
+		case "/api/repo":
+			require.Equal(t, "1", r.URL.Query().Get("page"))
+			fmt.Fprint(w, `{"name":"repo"}`)
+		case "/api/prefixed":
+			fmt.Fprint(w, ")]}'\n"+`{"name":"prefixed"}`)
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewRESTClient(srv.URL+"/api/", func(req *http.Request) {
+		req.Header.Set("Authorization", "token secret")
+	})
+
+	var v struct {
+		Name string `json:"name"`
+	}
+	require.NoError(t, c.Get("/repo", url.Values{"page": {"1"}}, &v))
+	require.Equal(t, "repo", v.Name)
+
+	require.ErrorContains(t, c.Get("/unknown", nil, &v), "status code: 404")
+	require.ErrorContains(t, c.Get("/prefixed", nil, &v), "failed to decode response")
+
+	c.Prefix = ")]}'"
+	require.NoError(t, c.Get("/prefixed", nil, &v))
+	require.Equal(t, "prefixed", v.Name)
+}
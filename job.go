@@ -0,0 +1,310 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"text/template"
+
+	"github.com/sj14/review-bot/config"
+	"github.com/sj14/review-bot/hoster"
+	"github.com/sj14/review-bot/notifier"
+)
+
+// runJob aggregates the reminders of the job and sends the message to all notify targets.
+// The ids of the messages which get edited or deleted on the next run are kept in the state.
+func runJob(cfg *config.Config, state *notifier.State, job config.Job) error {
+	hc := cfg.Hosters[job.Hoster]
+
+	h, err := hoster.New(hc.Provider, hoster.Config{
+		Host:      hc.Host,
+		Token:     hc.Token,
+		BaseURL:   hc.APIURL,
+		UploadURL: hc.UploadURL,
+		Options:   hc.Options,
+	})
+	if err != nil {
+		return fmt.Errorf("failed creating %v hoster: %w", hc.Provider, err)
+	}
+
+	reviewers := cfg.Reviewers[job.Reviewers]
+	if job.ReviewersFile != "" {
+		reviewers, err = loadReviewers(job.ReviewersFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	tmpl := h.DefaultTemplate()
+	if job.Template != "" {
+		tmpl, err = loadTemplate(job.Template)
+		if err != nil {
+			return err
+		}
+	}
+
+	repos := job.Repos
+	if len(job.Groups) > 0 {
+		discovered, err := discover(h, job.Groups, job.Filter, job.Topic)
+		if err != nil {
+			return fmt.Errorf("failed discovering %v repositories: %w", hc.Provider, err)
+		}
+		repos = append(repos, discovered...)
+	}
+
+	projects, err := hoster.Aggregate(h, repos, reviewers.Chats())
+	if err != nil {
+		return fmt.Errorf("failed aggregating %v reminders: %w", hc.Provider, err)
+	}
+	if len(projects) == 0 {
+		// prevent from sending the header only
+		return retract(state, job)
+	}
+
+	reminder, err := hoster.ExecProjects(tmpl, projects)
+	if err != nil {
+		return fmt.Errorf("failed executing template: %w", err)
+	}
+
+	if reminder == "" {
+		return retract(state, job)
+	}
+
+	fmt.Println(reminder)
+
+	digestTmpl := hoster.DefaultDigestTemplate()
+	if job.DigestTemplate != "" {
+		digestTmpl, err = loadTemplate(job.DigestTemplate)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, n := range job.Notify {
+		text, err := notifierText(h, job, n, projects, reminder)
+		if err != nil {
+			return err
+		}
+		nt, err := newNotifier(n, reviewers.Emails())
+		if err != nil {
+			return fmt.Errorf("failed creating %v notifier: %w", n.Kind(), err)
+		}
+		if err := notify(nt, n, state, stateKey(job, n), digestTmpl, projects, text); err != nil {
+			return fmt.Errorf("failed sending %v notification: %w", n.Kind(), err)
+		}
+	}
+
+	return nil
+}
+
+// newNotifier creates the notifier of the notify target,
+// the emails are the addresses of the reviewers by chat handle.
+func newNotifier(n config.Notify, emails map[string]string) (notifier.Notifier, error) {
+	return notifier.New(n.Kind(), notifier.Config{
+		Webhook: n.Webhook,
+		URL:     n.URL,
+		Token:   n.Token,
+		Channel: n.Channel,
+		Options: n.Options,
+		Emails:  emails,
+	})
+}
+
+// notifierText returns the reminder executed with the default template of the hoster for the notifier,
+// or the given reminder when there is none or a custom template is used.
+func notifierText(h hoster.Hoster, job config.Job, n config.Notify, projects []hoster.Project, reminder string) (string, error) {
+	nt, ok := h.(hoster.NotifierTemplater)
+	if !ok || job.Template != "" {
+		return reminder, nil
+	}
+
+	tmpl := nt.NotifierTemplate(n.Kind())
+	if tmpl == nil {
+		return reminder, nil
+	}
+
+	text, err := hoster.ExecProjects(tmpl, projects)
+	if err != nil {
+		return "", fmt.Errorf("failed executing %v template: %w", n.Kind(), err)
+	}
+	return text, nil
+}
+
+// stateKey returns the key of the previous message of the job in the channel of the notify target.
+func stateKey(job config.Job, n config.Notify) string {
+	return fmt.Sprintf("%s/%s/%s", job.Name, n.Kind(), n.Channel)
+}
+
+// notify sends the reminder to the channel of the notify target,
+// or a personal digest to each missing reviewer.
+func notify(nt notifier.Notifier, n config.Notify, state *notifier.State, key string, digestTmpl *template.Template, projects []hoster.Project, reminder string) error {
+	msg := notifier.Message{Text: reminder, Projects: projects}
+
+	if n.Previous != "" {
+		return replace(nt, n.Previous, state, key, msg)
+	}
+
+	if !n.Digest {
+		return nt.Send(msg)
+	}
+
+	dm, ok := nt.(notifier.DirectMessenger)
+	if !ok {
+		return errors.New("notifier doesn't support direct messages")
+	}
+
+	// try to reach all reviewers, even when a single one fails
+	var errs []error
+	for _, d := range hoster.Digests(projects) {
+		text, err := hoster.ExecDigest(digestTmpl, d)
+		if err != nil {
+			return err
+		}
+		if err := dm.SendDirect(d.Reviewer, notifier.Message{Text: text, Projects: d.Projects}); err != nil {
+			errs = append(errs, fmt.Errorf("failed sending digest to %v: %w", d.Reviewer, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// replace edits the previous message in place or deletes it before posting the new one.
+func replace(nt notifier.Notifier, previous string, state *notifier.State, key string, msg notifier.Message) error {
+	ed, ok := nt.(notifier.Editor)
+	if !ok {
+		return errors.New("notifier doesn't support editing messages")
+	}
+
+	id, err := state.Get(key)
+	if err != nil {
+		return err
+	}
+
+	if id != "" {
+		switch previous {
+		case config.PreviousEdit:
+			err := ed.Update(id, msg)
+			if err == nil {
+				return nil
+			}
+			// e.g. deleted manually in the meantime
+			log.Printf("failed editing previous message, posting a new one: %v", err)
+		case config.PreviousDelete:
+			if err := ed.Delete(id); err != nil {
+				log.Printf("failed deleting previous message: %v", err)
+			}
+		}
+	}
+
+	id, err = ed.Post(msg)
+	if err != nil {
+		return err
+	}
+	return state.Set(key, id)
+}
+
+// retract deletes the previous messages of the job when there is nothing to remind anymore.
+func retract(state *notifier.State, job config.Job) error {
+	for _, n := range job.Notify {
+		if n.Previous == "" {
+			continue
+		}
+
+		key := stateKey(job, n)
+		id, err := state.Get(key)
+		if err != nil {
+			return err
+		}
+		if id == "" {
+			continue
+		}
+
+		nt, err := newNotifier(n, nil)
+		if err != nil {
+			return err
+		}
+		ed, ok := nt.(notifier.Editor)
+		if !ok {
+			return fmt.Errorf("%v notifier doesn't support editing messages", n.Kind())
+		}
+		if err := ed.Delete(id); err != nil {
+			log.Printf("failed deleting previous %v message: %v", n.Kind(), err)
+		}
+		if err := state.Set(key, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// discover returns the repositories of all given groups which match the name filter and topic.
+func discover(h hoster.Hoster, groups []string, nameFilter, topic string) ([]string, error) {
+	d, ok := h.(hoster.Discoverer)
+	if !ok {
+		return nil, errors.New("hoster doesn't support groups")
+	}
+
+	filter := hoster.Filter{Topic: topic}
+	if nameFilter != "" {
+		re, err := regexp.Compile(nameFilter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile filter: %w", err)
+		}
+		filter.Name = re
+	}
+
+	var repos []string
+	for _, g := range groups {
+		discovered, err := d.Discover(g, filter)
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, discovered...)
+	}
+	return repos, nil
+}
+
+// legacyFields matches the GitLab/GitHub specific fields of templates before the
+// provider-neutral reminders (e.g. {{.MR.Title}}), which fail at execution.
+var legacyFields = regexp.MustCompile(`{{[^}]*\.(MR|PR|Project)\b[^}]*}}`)
+
+func loadTemplate(path string) (*template.Template, error) {
+	t, err := template.ParseFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template file: %w", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template file: %w", err)
+	}
+	if m := legacyFields.Find(b); m != nil {
+		return nil, fmt.Errorf("template %v uses the removed field in %s, see the migration notes of the README", path, m)
+	}
+	return t, nil
+}
+
+// load reviewers from given json file
+// formatting:
+// "GitLab UserID":"Mattermost Username"
+// e.g. {"3":"@john.doe","5":"@max"}
+// or
+// 'Github LoginName': 'Mattermost Username'
+// e.g. {"sj14":"@simon","john":"@john"}
+// or with the email address
+// e.g. {"sj14":{"chat":"@simon","email":"simon@example.com"}}
+func loadReviewers(path string) (config.Reviewers, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reviewers file: %w", err)
+	}
+
+	reviewers := config.Reviewers{}
+	if err := json.Unmarshal(b, &reviewers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reviewers: %w", err)
+	}
+
+	return reviewers, nil
+}
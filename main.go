@@ -1,121 +1,282 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
+	"maps"
 	"os"
+	"slices"
 	"strings"
-	"text/template"
 
-	"github.com/sj14/review-bot/hoster/github"
-	"github.com/sj14/review-bot/hoster/gitlab"
-	"github.com/sj14/review-bot/slackermost"
+	"github.com/sj14/review-bot/config"
+	"github.com/sj14/review-bot/hoster"
+	_ "github.com/sj14/review-bot/hoster/azure"
+	_ "github.com/sj14/review-bot/hoster/bitbucket"
+	_ "github.com/sj14/review-bot/hoster/gerrit"
+	_ "github.com/sj14/review-bot/hoster/gitea"
+	_ "github.com/sj14/review-bot/hoster/github"
+	_ "github.com/sj14/review-bot/hoster/gitlab"
+	"github.com/sj14/review-bot/notifier"
+	_ "github.com/sj14/review-bot/notifier/discord"
+	_ "github.com/sj14/review-bot/notifier/googlechat"
+	_ "github.com/sj14/review-bot/notifier/matrix"
+	_ "github.com/sj14/review-bot/notifier/mattermost"
+	_ "github.com/sj14/review-bot/notifier/rocketchat"
+	_ "github.com/sj14/review-bot/notifier/slack"
+	_ "github.com/sj14/review-bot/notifier/smtp"
+	_ "github.com/sj14/review-bot/notifier/teams"
+	_ "github.com/sj14/review-bot/notifier/webhook"
+	_ "github.com/sj14/review-bot/notifier/zulip"
 )
 
 func main() {
 	var (
-		host          = flag.String("host", "", "host address (e.g. github.com, gitlab.com or self-hosted gitlab url)")
+		configPath    = flag.String("config", "", "path to the YAML config file, other flags override its values")
+		serveMode     = flag.Bool("serve", false, "keep running and trigger the jobs on their schedule (see config file)")
+		listen        = flag.String("listen", ":8080", "address of the health endpoint in serve mode")
+		schedule      = flag.String("schedule", "", "cron expression when the jobs run in serve mode (e.g. '30 9 * * 1-5')")
+		timezone      = flag.String("timezone", "", "timezone of the schedule (e.g. 'Europe/Berlin'), defaults to the local timezone")
+		provider      = flag.String("provider", "", fmt.Sprintf("hoster type %v (default: github for github.com, otherwise gitlab)", hoster.Names()))
+		host          = flag.String("host", "", "host address (e.g. github.com, gitlab.com or self-hosted github/gitlab address)")
+		apiURL        = flag.String("api-url", "", "API base URL, derived from host when empty (e.g. https://github.example.com/api/v3/)")
+		uploadURL     = flag.String("upload-url", "", "GitHub Enterprise upload URL, defaults to the API base URL")
 		token         = flag.String("token", "", "host API token")
-		repo          = flag.String("repo", "", "repository (format: 'owner/repo'), or project id (only gitlab)")
+		options       = flag.String("options", "", "comma separated list of provider specific options (e.g. 'api=graphql' for github), prefix them with the provider when hosters of several providers are configured (e.g. 'github.api=graphql')")
+		repo          = flag.String("repo", "", "comma separated list of repositories (format: 'owner/repo'), or project ids (only gitlab)")
+		group         = flag.String("group", "", "comma separated list of gitlab groups (including subgroups) or github organisations/teams (format: 'org' or 'org/team') to discover repositories")
+		nameFilter    = flag.String("filter", "", "regular expression the path of discovered repositories has to match (e.g. '^org/backend-')")
+		topic         = flag.String("topic", "", "topic discovered repositories have to be tagged with")
 		reviewersPath = flag.String("reviewers", "examples/reviewers.json", "path to the reviewers file")
 		templatePath  = flag.String("template", "", "path to the template file")
-		webhook       = flag.String("webhook", "", "slack/mattermost webhook URL")
+		notifierType  = flag.String("notifier", "", fmt.Sprintf("notifier type %v (default: webhook)", notifier.Names()))
+		webhook       = flag.String("webhook", "", "incoming webhook URL of the notifier (e.g. slack, mattermost, teams or discord)")
+		notifierURL   = flag.String("notifier-url", "", "chat server URL of the notifier (e.g. https://mattermost.example.com)")
+		notifierToken = flag.String("notifier-token", "", "bot token of the notifier")
 		channelOrUser = flag.String("channel", "", "mattermost channel (e.g. MyChannel) or user (e.g. @AnyUser)")
+		digest        = flag.Bool("digest", false, "send each missing reviewer a direct message with their reminders (slack, mattermost or smtp notifier)")
+		digestPath    = flag.String("digest-template", "", "path to the template file of the personal digests")
+		previous      = flag.String("previous", "", "edit or delete the previous message of the job in the channel (slack, mattermost or matrix notifier)")
+		statePath     = flag.String("state", config.DefaultState, "path to the file with the ids of the previous messages")
 	)
 	flag.Parse()
 
-	if *host == "" {
-		log.Fatalln("missing host")
-	}
-	if *repo == "" {
-		log.Fatalln("missing repository")
+	cfg := &config.Config{
+		Hosters: map[string]config.Hoster{"default": {}},
+		Jobs:    []config.Job{{Name: "default", Hoster: "default"}},
 	}
+	// without a config file, all flags (including their defaults) are applied
+	isSet := func(name string) bool { return true }
 
-	reviewers := loadReviewers(*reviewersPath)
+	if *configPath != "" {
+		var err error
+		cfg, err = config.Load(*configPath)
+		if err != nil {
+			log.Fatalln(err)
+		}
 
-	var tmpl *template.Template
-	if *templatePath != "" {
-		tmpl = loadTemplate(*templatePath)
-	} else if *host == "github.com" {
-		tmpl = github.DefaultTemplate()
-	} else {
-		tmpl = gitlab.DefaultTemplate()
+		set := map[string]bool{}
+		flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+		isSet = func(name string) bool { return set[name] }
 	}
 
-	var reminder string
-	if *host == "github.com" {
-		ownerRespo := strings.SplitN(*repo, "/", 2)
-		if len(ownerRespo) != 2 {
-			log.Fatalln("wrong repo format (use 'owner/repo')")
-		}
-		repository, reminders, err := github.AggregateReminder(*token, ownerRespo[0], ownerRespo[1], reviewers)
+	var flagOptions map[string]string
+	if isSet("options") {
+		var err error
+		flagOptions, err = parseOptions(*options)
 		if err != nil {
-			log.Fatalf("failed aggregating github reminders: %v", err)
+			log.Fatalln(err)
 		}
-		if len(reminders) == 0 {
-			// prevent from sending the header only
-			return
+	}
+
+	// flags override the values of all hosters and jobs
+	for name, h := range cfg.Hosters {
+		if isSet("provider") {
+			h.Provider = *provider
 		}
-		reminder, err = github.ExecTemplate(tmpl, repository, reminders)
-		if err != nil {
-			log.Fatalf("failed executing template: %v", err)
+		if isSet("host") {
+			h.Host = *host
+		}
+		if isSet("api-url") {
+			h.APIURL = *apiURL
+		}
+		if isSet("upload-url") {
+			h.UploadURL = *uploadURL
+		}
+		if isSet("token") {
+			h.Token = *token
+		}
+		if h.Provider == "" {
+			h.Provider = defaultProvider(h.Host)
 		}
+		cfg.Hosters[name] = h
+	}
 
-	} else {
-		project, reminders, err := gitlab.AggregateReminder(*host, *token, *repo, reviewers)
-		if err != nil {
-			log.Fatalf("failed aggregating gitlab reminders: %v", err)
+	// the options only apply to the hosters of their provider
+	if flagOptions != nil {
+		providers := map[string]bool{}
+		for _, h := range cfg.Hosters {
+			providers[h.Provider] = true
 		}
-		if len(reminders) == 0 {
-			// prevent from sending the header only
-			return
+		for name, h := range cfg.Hosters {
+			opts, err := providerOptions(flagOptions, h.Provider, len(providers) == 1)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			h.Options = mergeOptions(h.Options, opts)
+			cfg.Hosters[name] = h
 		}
-		reminder, err = gitlab.ExecTemplate(tmpl, project, reminders)
-		if err != nil {
-			log.Fatalf("failed executing template: %v", err)
+	}
+
+	for i := range cfg.Jobs {
+		job := &cfg.Jobs[i]
+
+		if isSet("repo") {
+			job.Repos = splitList(*repo)
+		}
+		if isSet("group") {
+			job.Groups = splitList(*group)
+		}
+		if isSet("filter") {
+			job.Filter = *nameFilter
+		}
+		if isSet("topic") {
+			job.Topic = *topic
+		}
+		if isSet("reviewers") {
+			job.Reviewers = ""
+			job.ReviewersFile = *reviewersPath
+		}
+		if isSet("template") {
+			job.Template = *templatePath
 		}
+		if isSet("schedule") {
+			job.Schedule = *schedule
+		}
+		if isSet("timezone") {
+			job.Timezone = *timezone
+		}
+		if isSet("digest-template") {
+			job.DigestTemplate = *digestPath
+		}
+		if (isSet("webhook") || isSet("notifier")) && (*webhook != "" || *notifierType != "") {
+			job.Notify = []config.Notify{{
+				Type:     *notifierType,
+				Webhook:  *webhook,
+				URL:      *notifierURL,
+				Token:    *notifierToken,
+				Channel:  *channelOrUser,
+				Digest:   *digest,
+				Previous: *previous,
+			}}
+		} else {
+			for j := range job.Notify {
+				if isSet("channel") {
+					job.Notify[j].Channel = *channelOrUser
+				}
+				if isSet("previous") {
+					job.Notify[j].Previous = *previous
+				}
+			}
+		}
+	}
+	if isSet("state") {
+		cfg.State = *statePath
 	}
 
-	if reminder == "" {
-		return
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("invalid config: %v", err)
 	}
+	for _, job := range cfg.Jobs {
+		for _, n := range job.Notify {
+			if _, err := newNotifier(n, nil); err != nil {
+				log.Fatalf("invalid config: job %q: %v", job.Name, err)
+			}
+		}
+	}
+
+	state := notifier.NewState(cfg.StateFile())
 
-	fmt.Println(reminder)
+	if *serveMode {
+		if err := serve(cfg, state, *listen); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
 
-	if *webhook != "" {
-		if err := slackermost.Send(*channelOrUser, reminder, *webhook); err != nil {
-			log.Fatalf("failed sending slackermost message: %v", err)
+	failed := false
+	for _, job := range cfg.Jobs {
+		if err := runJob(cfg, state, job); err != nil {
+			log.Printf("job %q failed: %v", job.Name, err)
+			failed = true
 		}
 	}
+	if failed {
+		os.Exit(1)
+	}
 }
 
-func loadTemplate(path string) *template.Template {
-	t, err := template.ParseFiles(path)
-	if err != nil {
-		log.Fatalf("failed to read template file: %v", err)
+// splitList splits the comma separated list and drops empty entries.
+func splitList(list string) []string {
+	var entries []string
+	for _, e := range strings.Split(list, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			entries = append(entries, e)
+		}
 	}
-	return t
+	return entries
 }
 
-// load reviewers from given json file
-// formatting:
-// "GitLab UserID":"Mattermost Username"
-// e.g. {"3":"@john.doe","5":"@max"}
-// or
-// 'Github LoginName': 'Mattermost Username'
-// e.g. {"sj14":"@simon","john":"@john"}
-func loadReviewers(path string) map[string]string {
-	b, err := os.ReadFile(path)
-	if err != nil {
-		log.Fatalf("failed to read reviewers file: %v", err)
+// parseOptions parses the comma separated list of 'key=value' pairs.
+func parseOptions(list string) (map[string]string, error) {
+	opts := map[string]string{}
+	for _, e := range splitList(list) {
+		key, value, ok := strings.Cut(e, "=")
+		if !ok {
+			return nil, fmt.Errorf("wrong option format %q (use 'key=value')", e)
+		}
+		opts[strings.TrimSpace(key)] = strings.TrimSpace(value)
 	}
+	return opts, nil
+}
 
-	reviewers := map[string]string{}
-	if err := json.Unmarshal(b, &reviewers); err != nil {
-		log.Fatalf("failed to unmarshal reviewers: %v", err)
+// providerOptions returns the options which apply to hosters of the provider.
+// Options prefixed with a provider (e.g. 'github.api') only apply to this provider,
+// options without a prefix need all hosters to be of the same provider.
+func providerOptions(opts map[string]string, provider string, single bool) (map[string]string, error) {
+	scoped := map[string]string{}
+	for key, value := range opts {
+		prefix, name, ok := strings.Cut(key, ".")
+		if ok && !slices.Contains(hoster.Names(), prefix) {
+			return nil, fmt.Errorf("unknown provider %q of option %q (available: %v)", prefix, key, hoster.Names())
+		}
+		switch {
+		case ok && prefix == provider:
+			scoped[name] = value
+		case ok:
+			continue
+		case !single:
+			return nil, fmt.Errorf("ambiguous option %q with hosters of several providers (use e.g. '%v.%v')", key, provider, key)
+		default:
+			scoped[key] = value
+		}
 	}
+	return scoped, nil
+}
 
-	return reviewers
+// mergeOptions returns the options of the config file overridden by the given options.
+func mergeOptions(base, override map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(override))
+	maps.Copy(merged, base)
+	maps.Copy(merged, override)
+	return merged
+}
+
+// defaultProvider guesses the hoster type when not set explicitly.
+// github.com is the only known github host, everything else is treated as gitlab.
+func defaultProvider(host string) string {
+	if host == "github.com" {
+		return "github"
+	}
+	return "gitlab"
 }
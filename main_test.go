@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sj14/review-bot/hoster"
+	"github.com/sj14/review-bot/options"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderOptions(t *testing.T) {
+	opts := map[string]string{"github.api": "graphql", "gitlab.approvals": "true"}
+
+	got, err := providerOptions(opts, "github", false)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"api": "graphql"}, got)
+
+	got, err = providerOptions(opts, "gitea", false)
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	got, err = providerOptions(map[string]string{"api": "graphql"}, "github", true)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"api": "graphql"}, got)
+
+	_, err = providerOptions(map[string]string{"api": "graphql"}, "github", false)
+	require.ErrorContains(t, err, "ambiguous option")
+
+	_, err = providerOptions(map[string]string{"githb.api": "graphql"}, "github", true)
+	require.ErrorContains(t, err, "unknown provider")
+}
+
+func TestHosterUnknownOption(t *testing.T) {
+	for _, name := range hoster.Names() {
+		_, err := hoster.New(name, hoster.Config{Host: "example.com", Options: options.Options{"unknown": "true"}})
+		require.ErrorContains(t, err, "unknown option", name)
+	}
+}
+
+func TestExampleTemplates(t *testing.T) {
+	paths, err := filepath.Glob("examples/*.tmpl")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		tmpl, err := loadTemplate(path)
+		require.NoError(t, err, path)
+
+		got, err := hoster.ExecTemplate(tmpl, hoster.Repository{Name: "repo", URL: "https://example.com/repo"}, []hoster.Reminder{
+			{Number: 1, Title: "PR0", URL: "https://example.com/repo/1", Missing: []string{"@hulk"}, Discussions: 2, Emojis: map[string]int{"tada": 1}},
+			{Number: 2, Title: "PR1", URL: "https://example.com/repo/2", Owner: "@groot"},
+		})
+		require.NoError(t, err, path)
+		require.Contains(t, got, "repo", path)
+		require.Contains(t, got, "PR0", path)
+		require.Contains(t, got, "@hulk", path)
+	}
+}
+
+func TestLegacyTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "legacy.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte("{{range .Reminders}}{{ .MR.Title }}{{end}}"), 0o600))
+
+	_, err := loadTemplate(path)
+	require.ErrorContains(t, err, "migration")
+}
@@ -0,0 +1,132 @@
+package discord
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sj14/review-bot/notifier"
+)
+
+const (
+	httpTimeout = 15 * time.Second
+	// maxRetries when the webhook is rate limited
+	maxRetries = 3
+)
+
+func init() {
+	notifier.Register("discord", New)
+}
+
+// discord implements notifier.Notifier using a Discord webhook.
+type discord struct {
+	webhook string
+	http    *http.Client
+	now     func() time.Time
+	sleep   func(time.Duration)
+}
+
+// New returns a notifier posting the reminders as embeds to the webhook.
+func New(cfg notifier.Config) (notifier.Notifier, error) {
+	if cfg.Webhook == "" {
+		return nil, errors.New("missing discord webhook")
+	}
+
+	return &discord{
+		webhook: cfg.Webhook,
+		http:    &http.Client{Timeout: httpTimeout},
+		now:     time.Now,
+		sleep:   time.Sleep,
+	}, nil
+}
+
+// Send posts an embed per reminder, split across multiple messages when exceeding the limits.
+// The text of the message is posted above the embeds, it's empty with the default templates
+// as the embeds already contain the reminders.
+func (d *discord) Send(msg notifier.Message) error {
+	var embeds []payload
+	if len(msg.Projects) > 0 {
+		embeds = split(renderEmbeds(msg.Projects, d.now()))
+	}
+
+	var payloads []payload
+	chunks := splitText(strings.TrimSpace(msg.Text))
+	if len(chunks) > 0 && len(embeds) > 0 {
+		// the last chunk goes into the content of the first embeds message when it fits
+		last := chunks[len(chunks)-1]
+		if content := strings.TrimSpace(last + "\n" + embeds[0].Content); utf8.RuneCountInString(content) <= maxContent {
+			embeds[0].Content = content
+			chunks = chunks[:len(chunks)-1]
+		}
+	}
+	for _, chunk := range chunks {
+		payloads = append(payloads, payload{Content: chunk})
+	}
+	payloads = append(payloads, embeds...)
+
+	for i, p := range payloads {
+		if err := d.post(p); err != nil {
+			return fmt.Errorf("failed posting discord message %d/%d: %w", i+1, len(payloads), err)
+		}
+	}
+	return nil
+}
+
+// post sends the payload, rate limited requests are retried after the given time.
+func (d *discord) post(p payload) error {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		retryAfter, err := d.do(b)
+		if err == nil {
+			return nil
+		}
+		if retryAfter == 0 || attempt == maxRetries {
+			return err
+		}
+		d.sleep(retryAfter)
+	}
+}
+
+// do posts the body and returns the time to wait when rate limited.
+func (d *discord) do(body []byte) (time.Duration, error) {
+	resp, err := d.http.Post(d.webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("failed to close discord response body: %v\n", err)
+		}
+	}()
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return 0, nil
+	}
+
+	b, _ := io.ReadAll(resp.Body)
+	err = fmt.Errorf("status code: %v; body: %v", resp.StatusCode, string(b))
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return 0, err
+	}
+
+	var limit struct {
+		RetryAfter float64 `json:"retry_after"`
+	}
+	if json.Unmarshal(b, &limit) != nil || limit.RetryAfter <= 0 {
+		limit.RetryAfter = 1
+	}
+	return time.Duration(limit.RetryAfter * float64(time.Second)), err
+}
@@ -0,0 +1,92 @@
+package discord
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sj14/review-bot/hoster"
+	"github.com/sj14/review-bot/notifier"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend(t *testing.T) {
+	_, err := New(notifier.Config{})
+	require.Error(t, err)
+
+	var (
+		posted   []payload
+		limited  bool
+		requests int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if !limited {
+			limited = true
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"message":"You are being rate limited.","retry_after":0.5,"global":false}`)
+			return
+		}
+
+		var p payload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+		posted = append(posted, p)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	n, err := New(notifier.Config{Webhook: srv.URL})
+	require.NoError(t, err)
+
+	var slept []time.Duration
+	n.(*discord).sleep = func(d time.Duration) { slept = append(slept, d) }
+
+	var reminders []hoster.Reminder
+	for i := range 12 {
+		reminders = append(reminders, hoster.Reminder{Title: fmt.Sprint(i), Missing: []string{"80351110224678912"}})
+	}
+
+	require.NoError(t, n.Send(notifier.Message{Text: "reminder", Projects: []hoster.Project{{Repository: hoster.Repository{Name: "repo"}, Reminders: reminders}}}))
+	require.Equal(t, 3, requests)
+	require.Equal(t, []time.Duration{500 * time.Millisecond}, slept)
+	require.Len(t, posted, 2)
+	require.Equal(t, "reminder\n<@80351110224678912>", posted[0].Content)
+	require.Len(t, posted[0].Embeds, 10)
+	require.Len(t, posted[1].Embeds, 2)
+
+	// default template, only the embeds
+	posted = nil
+	require.NoError(t, n.Send(notifier.Message{Text: "\n\n", Projects: []hoster.Project{{Repository: hoster.Repository{Name: "repo"}, Reminders: reminders[:1]}}}))
+	require.Len(t, posted, 1)
+	require.Equal(t, "<@80351110224678912>", posted[0].Content)
+
+	// custom text exceeding the content limit
+	posted = nil
+	long := strings.Repeat("a", 1500) + "\n" + strings.Repeat("b", 1500)
+	require.NoError(t, n.Send(notifier.Message{Text: long, Projects: []hoster.Project{{Repository: hoster.Repository{Name: "repo"}, Reminders: reminders[:1]}}}))
+	require.Len(t, posted, 2)
+	require.Equal(t, strings.Repeat("a", 1500), posted[0].Content)
+	require.Empty(t, posted[0].Embeds)
+	require.Equal(t, strings.Repeat("b", 1500)+"\n<@80351110224678912>", posted[1].Content)
+	require.Len(t, posted[1].Embeds, 1)
+
+	// text only
+	posted = nil
+	require.NoError(t, n.Send(notifier.Message{Text: "digest"}))
+	require.Equal(t, []payload{{Content: "digest"}}, posted)
+}
+
+func TestSendError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Unknown Webhook","code":10015}`, http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	n, err := New(notifier.Config{Webhook: srv.URL})
+	require.NoError(t, err)
+	require.ErrorContains(t, n.Send(notifier.Message{Text: "reminder"}), "Unknown Webhook")
+}
@@ -0,0 +1,228 @@
+package discord
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sj14/review-bot/hoster"
+)
+
+// limits of a single webhook message
+const (
+	maxContent    = 2000
+	maxEmbeds     = 10
+	maxEmbedTotal = 6000
+	maxTitle      = 256
+	maxFieldValue = 1024
+)
+
+// colours of the embeds by the age of the request
+const (
+	colourFresh = 0x2ecc71 // green, less than 2 days
+	colourAging = 0xe67e22 // orange, less than a week
+	colourStale = 0xe74c3c // red
+)
+
+type embed struct {
+	Title     string  `json:"title"`
+	URL       string  `json:"url,omitempty"`
+	Color     int     `json:"color,omitempty"`
+	Author    *author `json:"author,omitempty"`
+	Fields    []field `json:"fields,omitempty"`
+	Timestamp string  `json:"timestamp,omitempty"`
+
+	mentions []string // of the missing reviewers or the owner
+}
+
+type author struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+type field struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// size returns the number of characters counting towards the embed limit of a message.
+func (e embed) size() int {
+	n := utf8.RuneCountInString(e.Title)
+	if e.Author != nil {
+		n += utf8.RuneCountInString(e.Author.Name)
+	}
+	for _, f := range e.Fields {
+		n += utf8.RuneCountInString(f.Name) + utf8.RuneCountInString(f.Value)
+	}
+	return n
+}
+
+// renderEmbeds returns an embed per reminder with the repository as author.
+func renderEmbeds(projects []hoster.Project, now time.Time) []embed {
+	var embeds []embed
+
+	for _, p := range projects {
+		for _, r := range p.Reminders {
+			e := embed{
+				Title:  truncate(r.Title, maxTitle),
+				URL:    r.URL,
+				Color:  colour(r.CreatedAt, now),
+				Author: &author{Name: truncate(p.Repository.Name, maxTitle), URL: p.Repository.URL},
+			}
+			if !r.CreatedAt.IsZero() {
+				e.Timestamp = r.CreatedAt.UTC().Format(time.RFC3339)
+			}
+
+			if len(r.Missing) == 0 {
+				owner := mention(r.Owner)
+				if owner != "" {
+					e.mentions = []string{owner}
+				}
+				e.Fields = append(e.Fields, field{Name: "Reviews", Value: truncate("You got all reviews, "+owner+".", maxFieldValue)})
+			} else {
+				for _, m := range r.Missing {
+					e.mentions = append(e.mentions, mention(m))
+				}
+				e.Fields = append(e.Fields, field{Name: "Missing reviewers", Value: truncate(strings.Join(e.mentions, " "), maxFieldValue), Inline: true})
+			}
+			if r.Discussions > 0 {
+				e.Fields = append(e.Fields, field{Name: "Discussions", Value: fmt.Sprintf("%d 💬", r.Discussions), Inline: true})
+			}
+
+			embeds = append(embeds, e)
+		}
+	}
+
+	return embeds
+}
+
+// payload is a single webhook message.
+type payload struct {
+	Content string  `json:"content,omitempty"`
+	Embeds  []embed `json:"embeds,omitempty"`
+}
+
+// split distributes the embeds across as few messages as the limits allow.
+// The content of each message mentions the reviewers of its embeds,
+// as mentions inside of embeds don't notify.
+func split(embeds []embed) []payload {
+	var (
+		payloads  []payload
+		current   payload
+		size      int
+		mentioned = map[string]bool{}
+	)
+
+	for _, e := range embeds {
+		if len(current.Embeds) > 0 &&
+			(len(current.Embeds) == maxEmbeds || size+e.size() > maxEmbedTotal || !fits(current.Content, e.mentions, mentioned)) {
+			payloads = append(payloads, current)
+			current, size, mentioned = payload{}, 0, map[string]bool{}
+		}
+
+		for _, m := range e.mentions {
+			if mentioned[m] {
+				continue
+			}
+			// mentions exceeding the limit of a single embed are only shown inside of the embed
+			if content := strings.TrimSpace(current.Content + " " + m); utf8.RuneCountInString(content) <= maxContent {
+				current.Content = content
+				mentioned[m] = true
+			}
+		}
+		current.Embeds = append(current.Embeds, e)
+		size += e.size()
+	}
+
+	if len(current.Embeds) > 0 {
+		payloads = append(payloads, current)
+	}
+	return payloads
+}
+
+// fits reports whether the mentions which are not mentioned yet fit into the content.
+func fits(content string, mentions []string, mentioned map[string]bool) bool {
+	n := utf8.RuneCountInString(content)
+	added := map[string]bool{}
+	for _, m := range mentions {
+		if mentioned[m] || added[m] {
+			continue
+		}
+		added[m] = true
+		n += 1 + utf8.RuneCountInString(m)
+	}
+	return n <= maxContent
+}
+
+// splitText splits the text at line breaks into chunks within the content limit.
+func splitText(text string) []string {
+	var (
+		chunks  []string
+		current string
+	)
+
+	for _, line := range strings.Split(text, "\n") {
+		for utf8.RuneCountInString(line) > maxContent {
+			r := []rune(line)
+			if current != "" {
+				chunks = append(chunks, current)
+				current = ""
+			}
+			chunks = append(chunks, string(r[:maxContent]))
+			line = string(r[maxContent:])
+		}
+
+		switch {
+		case current == "":
+			current = line
+		case utf8.RuneCountInString(current)+1+utf8.RuneCountInString(line) <= maxContent:
+			current += "\n" + line
+		default:
+			chunks = append(chunks, current)
+			current = line
+		}
+	}
+
+	if strings.TrimSpace(current) != "" {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// colour returns the colour by the age of the request.
+func colour(created, now time.Time) int {
+	if created.IsZero() {
+		return 0
+	}
+	switch age := now.Sub(created); {
+	case age < 2*24*time.Hour:
+		return colourFresh
+	case age < 7*24*time.Hour:
+		return colourAging
+	default:
+		return colourStale
+	}
+}
+
+var userID = regexp.MustCompile(`^(?:<@!?|@)?(\d{17,20})>?$`)
+
+// mention returns a real mention for Discord user ids (e.g. 80351110224678912),
+// other handles are kept as they are.
+func mention(handle string) string {
+	if m := userID.FindStringSubmatch(handle); m != nil {
+		return "<@" + m[1] + ">"
+	}
+	return handle
+}
+
+// truncate shortens the string to the maximum number of characters.
+func truncate(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	r := []rune(s)
+	return string(r[:max-1]) + "…"
+}
@@ -0,0 +1,102 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sj14/review-bot/hoster"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderEmbeds(t *testing.T) {
+	now := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	projects := []hoster.Project{{
+		Repository: hoster.Repository{Name: "repo", URL: "https://example.com/repo"},
+		Reminders: []hoster.Reminder{
+			{Title: "PR0", URL: "https://example.com/repo/0", CreatedAt: now.Add(-3 * 24 * time.Hour), Discussions: 2, Missing: []string{"@80351110224678912", "@hulk"}},
+			{Title: "PR1", Owner: "<@!80351110224678913>"},
+		},
+	}}
+
+	want := []embed{
+		{
+			Title:     "PR0",
+			URL:       "https://example.com/repo/0",
+			Color:     colourAging,
+			Author:    &author{Name: "repo", URL: "https://example.com/repo"},
+			Timestamp: "2024-03-07T09:00:00Z",
+			Fields: []field{
+				{Name: "Missing reviewers", Value: "<@80351110224678912> @hulk", Inline: true},
+				{Name: "Discussions", Value: "2 💬", Inline: true},
+			},
+			mentions: []string{"<@80351110224678912>", "@hulk"},
+		},
+		{
+			Title:    "PR1",
+			Author:   &author{Name: "repo", URL: "https://example.com/repo"},
+			Fields:   []field{{Name: "Reviews", Value: "You got all reviews, <@80351110224678913>."}},
+			mentions: []string{"<@80351110224678913>"},
+		},
+	}
+
+	require.Equal(t, want, renderEmbeds(projects, now))
+}
+
+func TestColour(t *testing.T) {
+	now := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+	require.Equal(t, 0, colour(time.Time{}, now))
+	require.Equal(t, colourFresh, colour(now.Add(-time.Hour), now))
+	require.Equal(t, colourAging, colour(now.Add(-2*24*time.Hour), now))
+	require.Equal(t, colourStale, colour(now.Add(-7*24*time.Hour), now))
+}
+
+func TestSplit(t *testing.T) {
+	var embeds []embed
+	for i := range 25 {
+		embeds = append(embeds, embed{Title: fmt.Sprint(i), mentions: []string{"<@80351110224678912>"}})
+	}
+
+	payloads := split(embeds)
+	require.Len(t, payloads, 3)
+	require.Len(t, payloads[0].Embeds, 10)
+	require.Len(t, payloads[2].Embeds, 5)
+	for _, p := range payloads {
+		require.Equal(t, "<@80351110224678912>", p.Content, "mentioned once per message")
+	}
+
+	// mentions exceeding the content limit
+	long := strings.Repeat("a", 1500)
+	payloads = split([]embed{
+		{Title: "0", mentions: []string{"@" + long}},
+		{Title: "1", mentions: []string{"@" + long}},
+		{Title: "2", mentions: []string{"@" + long, "@" + strings.Repeat("b", 1500)}},
+	})
+	require.Len(t, payloads, 2)
+	require.Len(t, payloads[0].Embeds, 2)
+	require.Equal(t, "@"+long, payloads[1].Content)
+
+	// embed size limit
+	payloads = split([]embed{{Title: strings.Repeat("a", 4000)}, {Title: strings.Repeat("b", 4000)}})
+	require.Len(t, payloads, 2)
+}
+
+func TestSplitText(t *testing.T) {
+	require.Empty(t, splitText(""))
+	require.Equal(t, []string{"line0\nline1"}, splitText("line0\nline1"))
+
+	line := strings.Repeat("a", 1500)
+	require.Equal(t, []string{line, line}, splitText(line+"\n"+line))
+
+	long := strings.Repeat("b", 4500)
+	require.Equal(t, []string{"line", long[:2000], long[2000:4000], long[4000:]}, splitText("line\n"+long))
+}
+
+func TestMention(t *testing.T) {
+	require.Equal(t, "<@80351110224678912>", mention("80351110224678912"))
+	require.Equal(t, "<@80351110224678912>", mention("@80351110224678912"))
+	require.Equal(t, "<@80351110224678912>", mention("<@80351110224678912>"))
+	require.Equal(t, "@hulk", mention("@hulk"))
+}
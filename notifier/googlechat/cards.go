@@ -0,0 +1,133 @@
+package googlechat
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/sj14/review-bot/hoster"
+)
+
+type message struct {
+	Text    string   `json:"text,omitempty"`
+	CardsV2 []cardV2 `json:"cardsV2,omitempty"`
+}
+
+type cardV2 struct {
+	CardID string `json:"cardId"`
+	Card   card   `json:"card"`
+}
+
+type card struct {
+	Header   *header   `json:"header,omitempty"`
+	Sections []section `json:"sections"`
+}
+
+type header struct {
+	Title string `json:"title"`
+}
+
+type section struct {
+	Widgets []widget `json:"widgets"`
+}
+
+type widget struct {
+	DecoratedText *decoratedText `json:"decoratedText,omitempty"`
+}
+
+type decoratedText struct {
+	TopLabel    string  `json:"topLabel,omitempty"`
+	Text        string  `json:"text"`
+	BottomLabel string  `json:"bottomLabel,omitempty"`
+	WrapText    bool    `json:"wrapText"`
+	Button      *button `json:"button,omitempty"`
+}
+
+type button struct {
+	Text    string  `json:"text"`
+	OnClick onClick `json:"onClick"`
+}
+
+type onClick struct {
+	OpenLink openLink `json:"openLink"`
+}
+
+type openLink struct {
+	URL string `json:"url"`
+}
+
+// renderMessage returns a card per repository with a widget per reminder.
+// Mentions inside of cards don't notify, the users are mentioned in the text of the message.
+func renderMessage(projects []hoster.Project) message {
+	var (
+		msg      message
+		mentions []string
+	)
+
+	for i, p := range projects {
+		var widgets []widget
+		for _, r := range p.Reminders {
+			dt := &decoratedText{Text: link(r.URL, r.Title), WrapText: true}
+			if r.Discussions > 0 {
+				dt.TopLabel = fmt.Sprintf("%d 💬", r.Discussions)
+			}
+			if r.URL != "" {
+				dt.Button = &button{Text: "Review", OnClick: onClick{OpenLink: openLink{URL: r.URL}}}
+			}
+
+			handles := r.Missing
+			switch len(r.Missing) {
+			case 0:
+				dt.BottomLabel = "You got all reviews."
+				if r.Owner != "" {
+					handles = []string{r.Owner}
+				}
+			case 1:
+				dt.BottomLabel = "1 review missing"
+			default:
+				dt.BottomLabel = fmt.Sprintf("%d reviews missing", len(r.Missing))
+			}
+			for _, h := range handles {
+				if m := mention(h); !slices.Contains(mentions, m) {
+					mentions = append(mentions, m)
+				}
+			}
+
+			widgets = append(widgets, widget{DecoratedText: dt})
+		}
+
+		msg.CardsV2 = append(msg.CardsV2, cardV2{
+			CardID: fmt.Sprintf("repository-%d", i),
+			Card: card{
+				Header:   &header{Title: p.Repository.Name},
+				Sections: []section{{Widgets: widgets}},
+			},
+		})
+	}
+
+	if len(mentions) > 0 {
+		msg.Text = "Pending reviews: " + strings.Join(mentions, " ")
+	}
+	return msg
+}
+
+// link returns the HTML link of the card text, or only the escaped text without an URL.
+func link(url, title string) string {
+	if url == "" {
+		return html.EscapeString(title)
+	}
+	return `<a href="` + html.EscapeString(url) + `">` + html.EscapeString(title) + "</a>"
+}
+
+var userID = regexp.MustCompile(`^@?(?:users/)?(\d+)$`)
+
+// mention returns a real mention for Google Chat user ids (e.g. users/123456789),
+// other handles are kept as they are.
+func mention(handle string) string {
+	if m := userID.FindStringSubmatch(handle); m != nil {
+		return "<users/" + m[1] + ">"
+	}
+	return handle
+}
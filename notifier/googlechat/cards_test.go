@@ -0,0 +1,46 @@
+package googlechat
+
+import (
+	"testing"
+
+	"github.com/sj14/review-bot/hoster"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderMessage(t *testing.T) {
+	projects := []hoster.Project{
+		{Repository: hoster.Repository{Name: "repo0"}, Reminders: []hoster.Reminder{
+			{Title: "Fix <script>", URL: "https://example.com/0", Discussions: 2, Missing: []string{"users/123456789", "@hulk"}},
+			{Title: "PR1", Missing: []string{"@123456789"}},
+		}},
+		{Repository: hoster.Repository{Name: "repo1"}, Reminders: []hoster.Reminder{
+			{Title: "PR2", URL: "https://example.com/2", Owner: "users/987654321"},
+		}},
+	}
+
+	want := message{
+		Text: "Pending reviews: <users/123456789> @hulk <users/987654321>",
+		CardsV2: []cardV2{
+			{CardID: "repository-0", Card: card{Header: &header{Title: "repo0"}, Sections: []section{{Widgets: []widget{
+				{DecoratedText: &decoratedText{
+					TopLabel:    "2 💬",
+					Text:        `<a href="https://example.com/0">Fix &lt;script&gt;</a>`,
+					BottomLabel: "2 reviews missing",
+					WrapText:    true,
+					Button:      &button{Text: "Review", OnClick: onClick{OpenLink: openLink{URL: "https://example.com/0"}}},
+				}},
+				{DecoratedText: &decoratedText{Text: "PR1", BottomLabel: "1 review missing", WrapText: true}},
+			}}}}},
+			{CardID: "repository-1", Card: card{Header: &header{Title: "repo1"}, Sections: []section{{Widgets: []widget{
+				{DecoratedText: &decoratedText{
+					Text:        `<a href="https://example.com/2">PR2</a>`,
+					BottomLabel: "You got all reviews.",
+					WrapText:    true,
+					Button:      &button{Text: "Review", OnClick: onClick{OpenLink: openLink{URL: "https://example.com/2"}}},
+				}},
+			}}}}},
+		},
+	}
+
+	require.Equal(t, want, renderMessage(projects))
+}
@@ -0,0 +1,87 @@
+package googlechat
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sj14/review-bot/notifier"
+	"github.com/sj14/review-bot/notifier/markdown"
+)
+
+const httpTimeout = 15 * time.Second
+
+func init() {
+	notifier.Register("googlechat", New)
+}
+
+// googlechat implements notifier.Notifier using a Google Chat incoming webhook.
+type googlechat struct {
+	webhook string
+	http    *http.Client
+}
+
+// New returns a notifier posting the reminders as cards to the webhook of the space.
+func New(cfg notifier.Config) (notifier.Notifier, error) {
+	if cfg.Webhook == "" {
+		return nil, errors.New("missing google chat webhook")
+	}
+
+	return &googlechat{
+		webhook: cfg.Webhook,
+		http:    &http.Client{Timeout: httpTimeout},
+	}, nil
+}
+
+// Send posts a card per repository with the text of the message above the cards.
+// The text is empty with the default templates, as the cards already contain the reminders.
+func (g *googlechat) Send(msg notifier.Message) error {
+	var payload message
+	if len(msg.Projects) > 0 {
+		payload = renderMessage(msg.Projects)
+	}
+
+	// the mentions stay below the text to notify the reviewers
+	var text []string
+	for _, t := range []string{markdown.ToMrkdwn(strings.TrimSpace(msg.Text)), payload.Text} {
+		if t != "" {
+			text = append(text, t)
+		}
+	}
+	payload.Text = strings.Join(text, "\n\n")
+
+	if err := g.post(payload); err != nil {
+		return fmt.Errorf("failed posting google chat message: %w", err)
+	}
+	return nil
+}
+
+func (g *googlechat) post(payload message) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	resp, err := g.http.Post(g.webhook, "application/json; charset=UTF-8", bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("failed to close google chat response body: %v\n", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status code: %v; body: %v", resp.StatusCode, string(body))
+	}
+	return nil
+}
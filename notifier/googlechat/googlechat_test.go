@@ -0,0 +1,54 @@
+package googlechat
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sj14/review-bot/hoster"
+	"github.com/sj14/review-bot/notifier"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend(t *testing.T) {
+	_, err := New(notifier.Config{})
+	require.Error(t, err)
+
+	var got message
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = message{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		if r.URL.Query().Get("token") == "invalid" {
+			http.Error(w, `{"error":{"code":400,"message":"Invalid JSON payload"}}`, http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"name":"spaces/AAA/messages/BBB"}`))
+	}))
+	defer srv.Close()
+
+	n, err := New(notifier.Config{Webhook: srv.URL + "/v1/spaces/AAA/messages?token=valid"})
+	require.NoError(t, err)
+
+	require.NoError(t, n.Send(notifier.Message{Text: "**digest**"}))
+	require.Equal(t, message{Text: "*digest*"}, got)
+
+	require.NoError(t, n.Send(notifier.Message{Text: "reminder", Projects: []hoster.Project{{
+		Repository: hoster.Repository{Name: "repo"},
+		Reminders:  []hoster.Reminder{{Title: "PR", Missing: []string{"users/123456789"}}},
+	}}}))
+	require.Equal(t, "reminder\n\nPending reviews: <users/123456789>", got.Text)
+	require.Len(t, got.CardsV2, 1)
+
+	// default template, only the cards and mentions
+	require.NoError(t, n.Send(notifier.Message{Text: "\n\n", Projects: []hoster.Project{{
+		Repository: hoster.Repository{Name: "repo"},
+		Reminders:  []hoster.Reminder{{Title: "PR", Missing: []string{"users/123456789"}}},
+	}}}))
+	require.Equal(t, "Pending reviews: <users/123456789>", got.Text)
+	require.Len(t, got.CardsV2, 1)
+
+	n, err = New(notifier.Config{Webhook: srv.URL + "/v1/spaces/AAA/messages?token=invalid"})
+	require.NoError(t, err)
+	require.ErrorContains(t, n.Send(notifier.Message{Text: "reminder"}), "Invalid JSON payload")
+}
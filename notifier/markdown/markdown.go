@@ -0,0 +1,135 @@
+// Package markdown converts the markdown of the templates to HTML or Slack-like markup
+// for notifiers which don't render markdown themselves.
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	heading = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	rule    = regexp.MustCompile(`^(-{3,}|\*{3,}|_{3,})$`)
+	item    = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	// images are dropped, the size syntax of the templates (e.g. =40x) is Mattermost specific
+	image  = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+	link   = regexp.MustCompile(`\[([^\]]*)\]\(([^)\s]+)\)`)
+	bold   = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	italic = regexp.MustCompile(`\*([^*\s](?:[^*]*[^*\s])?)\*`)
+)
+
+// ToHTML converts the markdown of the templates (headings, rules, lists, links, bold and italic text) to HTML.
+// The optional text func converts the escaped text outside of links (e.g. to mention users).
+func ToHTML(md string, text func(string) string) string {
+	if text == nil {
+		text = func(s string) string { return s }
+	}
+
+	var (
+		blocks    []string
+		paragraph []string
+		list      []string
+	)
+
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, "<p>"+strings.Join(paragraph, "<br>")+"</p>")
+			paragraph = nil
+		}
+		if len(list) > 0 {
+			blocks = append(blocks, "<ul><li>"+strings.Join(list, "</li><li>")+"</li></ul>")
+			list = nil
+		}
+	}
+
+	for _, line := range strings.Split(md, "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "":
+			flush()
+		case rule.MatchString(line):
+			flush()
+			blocks = append(blocks, "<hr>")
+		case heading.MatchString(line):
+			flush()
+			m := heading.FindStringSubmatch(line)
+			level := string(rune('0' + len(m[1])))
+			blocks = append(blocks, "<h"+level+">"+inline(m[2], text)+"</h"+level+">")
+		case item.MatchString(line):
+			if len(paragraph) > 0 {
+				flush()
+			}
+			list = append(list, inline(item.FindStringSubmatch(line)[1], text))
+		default:
+			if len(list) > 0 {
+				flush()
+			}
+			paragraph = append(paragraph, inline(line, text))
+		}
+	}
+	flush()
+
+	return strings.Join(blocks, "")
+}
+
+// inline converts the links and emphasis of a single line.
+func inline(s string, text func(string) string) string {
+	s = strings.TrimSpace(image.ReplaceAllString(s, ""))
+
+	var b strings.Builder
+	for {
+		loc := link.FindStringSubmatchIndex(s)
+		if loc == nil {
+			b.WriteString(text(html.EscapeString(s)))
+			break
+		}
+
+		b.WriteString(text(html.EscapeString(s[:loc[0]])))
+		title, url := s[loc[2]:loc[3]], s[loc[4]:loc[5]]
+		b.WriteString(`<a href="` + html.EscapeString(url) + `">` + html.EscapeString(title) + "</a>")
+		s = s[loc[1]:]
+	}
+
+	// emphasis can enclose links
+	out := bold.ReplaceAllString(b.String(), "<strong>$1</strong>")
+	return italic.ReplaceAllString(out, "<em>$1</em>")
+}
+
+// ToMrkdwn converts the markdown of the templates to the markup of Slack-like chats
+// (*bold*, _italic_ and <url|title> links). Headings are shown bold and rules are dropped.
+func ToMrkdwn(md string) string {
+	var lines []string
+
+	for _, line := range strings.Split(md, "\n") {
+		line = strings.TrimSpace(image.ReplaceAllString(line, ""))
+
+		switch {
+		case rule.MatchString(line):
+			continue
+		case heading.MatchString(line):
+			line = "*" + strings.ReplaceAll(mrkdwnInline(heading.FindStringSubmatch(line)[2]), "*", "") + "*"
+		case item.MatchString(line):
+			line = "• " + mrkdwnInline(item.FindStringSubmatch(line)[1])
+		default:
+			line = mrkdwnInline(line)
+		}
+
+		// collapse the blank lines of dropped rules
+		if line == "" && (len(lines) == 0 || lines[len(lines)-1] == "") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func mrkdwnInline(s string) string {
+	s = link.ReplaceAllString(s, "<$2|$1>")
+	// mark bold text, the asterisks would be taken as italic otherwise
+	s = bold.ReplaceAllString(s, "\x00$1\x00")
+	s = italic.ReplaceAllString(s, "_${1}_")
+	return strings.ReplaceAll(s, "\x00", "*")
+}
@@ -0,0 +1,58 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToHTML(t *testing.T) {
+	md := `
+# ![](https://gitlab.com/avatar.png =40x) [repo](https://gitlab.com/owner/repo)
+
+**How-To**: *Got reminded? Just review the given merge request.*
+
+---
+
+**[Fix <script> & more](https://gitlab.com/owner/repo/-/merge_requests/1)**
+ 2 💬  @hulk:example.com @groot 
+
+- [PR2](https://gitlab.com/2) @iron_man:example.com
+- PR3
+`
+
+	want := `<h1><a href="https://gitlab.com/owner/repo">repo</a></h1>` +
+		`<p><strong>How-To</strong>: <em>Got reminded? Just review the given merge request.</em></p>` +
+		`<hr>` +
+		`<p><strong><a href="https://gitlab.com/owner/repo/-/merge_requests/1">Fix &lt;script&gt; &amp; more</a></strong><br>` +
+		`2 💬  <b>@hulk</b>:example.com @groot</p>` +
+		`<ul><li><a href="https://gitlab.com/2">PR2</a> @iron_man:example.com</li><li>PR3</li></ul>`
+
+	require.Equal(t, "<p>a &amp; <em>b</em></p>", ToHTML("a & *b*", nil))
+
+	require.Equal(t, want, ToHTML(md, func(s string) string { return strings.ReplaceAll(s, "@hulk", "<b>@hulk</b>") }))
+}
+
+func TestToMrkdwn(t *testing.T) {
+	md := `
+# ![](https://gitlab.com/avatar.png =40x) [repo](https://gitlab.com/owner/repo)
+
+**How-To**: *Got reminded? Just review the given merge request.*
+
+---
+
+**[PR1](https://gitlab.com/1)**
+ 2 💬  @hulk @groot 
+
+- [PR2](https://gitlab.com/2) @iron_man
+`
+
+	want := "*<https://gitlab.com/owner/repo|repo>*\n\n" +
+		"*How-To*: _Got reminded? Just review the given merge request._\n\n" +
+		"*<https://gitlab.com/1|PR1>*\n" +
+		"2 💬  @hulk @groot\n\n" +
+		"• <https://gitlab.com/2|PR2> @iron_man"
+
+	require.Equal(t, want, ToMrkdwn(md))
+}
@@ -0,0 +1,245 @@
+package matrix
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sj14/review-bot/notifier"
+	"github.com/sj14/review-bot/notifier/markdown"
+)
+
+const httpTimeout = 15 * time.Second
+
+func init() {
+	notifier.Register("matrix", New)
+}
+
+// matrix implements notifier.Notifier using the Matrix client-server API.
+type matrix struct {
+	baseURL string
+	token   string
+	room    string
+	http    *http.Client
+	txn     atomic.Int64
+
+	mu     sync.Mutex
+	roomID string
+}
+
+// New returns a notifier sending with the access token to the room
+// (room id or alias, e.g. #backend:example.com) of the homeserver at the URL.
+func New(cfg notifier.Config) (notifier.Notifier, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("missing matrix homeserver url")
+	}
+	if cfg.Token == "" {
+		return nil, errors.New("missing matrix access token")
+	}
+
+	m := &matrix{
+		baseURL: strings.TrimSuffix(cfg.URL, "/") + "/_matrix/client/v3",
+		token:   cfg.Token,
+		room:    cfg.Channel,
+		http:    &http.Client{Timeout: httpTimeout},
+	}
+	// transaction ids have to be unique per access token, also across restarts
+	m.txn.Store(time.Now().UnixNano())
+	return m, nil
+}
+
+type content struct {
+	MsgType       string       `json:"msgtype"`
+	Body          string       `json:"body"`
+	Format        string       `json:"format,omitempty"`
+	FormattedBody string       `json:"formatted_body,omitempty"`
+	Mentions      *mentionList `json:"m.mentions,omitempty"`
+	NewContent    *content     `json:"m.new_content,omitempty"`
+	RelatesTo     *relation    `json:"m.relates_to,omitempty"`
+}
+
+type mentionList struct {
+	UserIDs []string `json:"user_ids"`
+}
+
+type relation struct {
+	RelType string `json:"rel_type"`
+	EventID string `json:"event_id"`
+}
+
+// newContent returns the text as plain body and as HTML converted from markdown.
+func newContent(text string) content {
+	return content{
+		MsgType:       "m.text",
+		Body:          text,
+		Format:        "org.matrix.custom.html",
+		FormattedBody: markdown.ToHTML(text, pills),
+		Mentions:      &mentionList{UserIDs: mentions(text)},
+	}
+}
+
+// Send sends the message to the room.
+func (m *matrix) Send(msg notifier.Message) error {
+	_, err := m.Post(msg)
+	return err
+}
+
+// Post sends the message to the room and returns the event id.
+func (m *matrix) Post(msg notifier.Message) (string, error) {
+	eventID, err := m.send(newContent(msg.Text))
+	if err != nil {
+		return "", fmt.Errorf("failed sending matrix message: %w", err)
+	}
+	return eventID, nil
+}
+
+// Update replaces the message of the event with the given id.
+func (m *matrix) Update(eventID string, msg notifier.Message) error {
+	c := newContent(msg.Text)
+	// clients without support for edits show the fallback
+	edit := content{
+		MsgType:       c.MsgType,
+		Body:          "* " + c.Body,
+		Format:        c.Format,
+		FormattedBody: "* " + c.FormattedBody,
+		Mentions:      c.Mentions,
+		NewContent:    &c,
+		RelatesTo:     &relation{RelType: "m.replace", EventID: eventID},
+	}
+
+	if _, err := m.send(edit); err != nil {
+		return fmt.Errorf("failed editing matrix message: %w", err)
+	}
+	return nil
+}
+
+// Delete redacts the event with the given id.
+func (m *matrix) Delete(eventID string) error {
+	roomID, err := m.roomByAlias()
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/rooms/%s/redact/%s/%d", url.PathEscape(roomID), url.PathEscape(eventID), m.txn.Add(1))
+	if err := m.do(http.MethodPut, path, struct{}{}, nil); err != nil {
+		return fmt.Errorf("failed redacting matrix message: %w", err)
+	}
+	return nil
+}
+
+// send sends the message event to the room and returns the event id.
+func (m *matrix) send(c content) (string, error) {
+	roomID, err := m.roomByAlias()
+	if err != nil {
+		return "", err
+	}
+
+	var resp struct {
+		EventID string `json:"event_id"`
+	}
+	path := fmt.Sprintf("/rooms/%s/send/m.room.message/%d", url.PathEscape(roomID), m.txn.Add(1))
+	if err := m.do(http.MethodPut, path, c, &resp); err != nil {
+		return "", err
+	}
+	return resp.EventID, nil
+}
+
+// roomByAlias returns the id of the configured room, aliases (e.g. #backend:example.com) are resolved.
+func (m *matrix) roomByAlias() (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.room == "" {
+		return "", errors.New("missing matrix room")
+	}
+	if m.roomID != "" {
+		return m.roomID, nil
+	}
+	if !strings.HasPrefix(m.room, "#") {
+		m.roomID = m.room
+		return m.roomID, nil
+	}
+
+	var room struct {
+		RoomID string `json:"room_id"`
+	}
+	if err := m.do(http.MethodGet, "/directory/room/"+url.PathEscape(m.room), nil, &room); err != nil {
+		return "", fmt.Errorf("failed resolving matrix room %v: %w", m.room, err)
+	}
+	m.roomID = room.RoomID
+	return m.roomID, nil
+}
+
+// do sends the payload to the API path and decodes the response into v.
+func (m *matrix) do(method, path string, payload, v interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal payload: %w", err)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, m.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+m.token)
+
+	resp, err := m.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("failed to close matrix response body: %v\n", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status code: %v; body: %v", resp.StatusCode, string(body))
+	}
+
+	if v == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+var userID = regexp.MustCompile(`@[a-z0-9._=\-/+]+:[a-zA-Z0-9.\-]+(?::\d+)?`)
+
+// pills converts the user ids in the text to links which clients render as pills.
+func pills(text string) string {
+	return userID.ReplaceAllStringFunc(text, func(id string) string {
+		return `<a href="https://matrix.to/#/` + id + `">` + id + "</a>"
+	})
+}
+
+// mentions returns the user ids in the text.
+func mentions(text string) []string {
+	var ids []string
+	for _, id := range userID.FindAllString(text, -1) {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,97 @@
+package matrix
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sj14/review-bot/notifier"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(notifier.Config{Token: "secret"})
+	require.Error(t, err)
+
+	_, err = New(notifier.Config{URL: "https://matrix.example.com"})
+	require.Error(t, err)
+
+	n, err := New(notifier.Config{URL: "https://matrix.example.com/", Token: "secret"})
+	require.NoError(t, err)
+	require.Equal(t, "https://matrix.example.com/_matrix/client/v3", n.(*matrix).baseURL)
+	require.Error(t, n.Send(notifier.Message{Text: "reminder"}), "missing room")
+}
+
+func TestSend(t *testing.T) {
+	var (
+		sent       []content
+		txns       = map[string]bool{}
+		aliasCalls int
+		redacted   []string
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /_matrix/client/v3/directory/room/{alias}", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		require.Equal(t, "#backend:example.com", r.PathValue("alias"))
+		aliasCalls++
+		fmt.Fprint(w, `{"room_id":"!room:example.com","servers":["example.com"]}`)
+	})
+	mux.HandleFunc("PUT /_matrix/client/v3/rooms/{room}/send/m.room.message/{txn}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("room") != "!room:example.com" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"errcode":"M_FORBIDDEN","error":"User not in room"}`)
+			return
+		}
+		require.False(t, txns[r.PathValue("txn")], "transaction id reused")
+		txns[r.PathValue("txn")] = true
+
+		var c content
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&c))
+		sent = append(sent, c)
+		fmt.Fprintf(w, `{"event_id":"$event%d"}`, len(sent))
+	})
+	mux.HandleFunc("PUT /_matrix/client/v3/rooms/{room}/redact/{event}/{txn}", func(w http.ResponseWriter, r *http.Request) {
+		redacted = append(redacted, r.PathValue("event"))
+		fmt.Fprint(w, `{"event_id":"$redaction"}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	n, err := New(notifier.Config{URL: srv.URL, Token: "secret", Channel: "#backend:example.com"})
+	require.NoError(t, err)
+	ed := n.(notifier.Editor)
+
+	id, err := ed.Post(notifier.Message{Text: "**[PR](https://example.com/1)** @hulk:example.com"})
+	require.NoError(t, err)
+	require.Equal(t, "$event1", id)
+	require.NoError(t, ed.Update(id, notifier.Message{Text: "updated"}))
+	require.NoError(t, ed.Delete(id))
+	require.Equal(t, 1, aliasCalls, "the alias is resolved once")
+
+	require.Equal(t, content{
+		MsgType:       "m.text",
+		Body:          "**[PR](https://example.com/1)** @hulk:example.com",
+		Format:        "org.matrix.custom.html",
+		FormattedBody: `<p><strong><a href="https://example.com/1">PR</a></strong> <a href="https://matrix.to/#/@hulk:example.com">@hulk:example.com</a></p>`,
+		Mentions:      &mentionList{UserIDs: []string{"@hulk:example.com"}},
+	}, sent[0])
+
+	require.Equal(t, "* updated", sent[1].Body)
+	require.Equal(t, &relation{RelType: "m.replace", EventID: "$event1"}, sent[1].RelatesTo)
+	require.Equal(t, "updated", sent[1].NewContent.Body)
+	require.Equal(t, []string{"$event1"}, redacted)
+
+	n, err = New(notifier.Config{URL: srv.URL, Token: "secret", Channel: "!unknown:example.com"})
+	require.NoError(t, err)
+	require.ErrorContains(t, n.Send(notifier.Message{Text: "reminder"}), "M_FORBIDDEN")
+}
+
+func TestMentions(t *testing.T) {
+	require.Equal(t, []string{"@hulk:example.com", "@groot:matrix.org:8448"}, mentions("@hulk:example.com @hulk:example.com @groot:matrix.org:8448 @batman"))
+	require.Empty(t, mentions("nobody"))
+	require.Equal(t, `<a href="https://matrix.to/#/@hulk:example.com">@hulk:example.com</a> @batman`, pills("@hulk:example.com @batman"))
+}
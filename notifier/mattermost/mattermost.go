@@ -0,0 +1,216 @@
+package mattermost
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sj14/review-bot/notifier"
+)
+
+const httpTimeout = 15 * time.Second
+
+func init() {
+	notifier.Register("mattermost", New)
+}
+
+// mattermost implements notifier.Notifier using the Mattermost API.
+type mattermost struct {
+	baseURL string
+	token   string
+	channel string
+	http    *http.Client
+
+	mu        sync.Mutex
+	botUserID string
+	channelID string
+}
+
+// New returns a notifier posting with the bot token to the channel
+// (channel id or 'team/channel' name) of the Mattermost server at the URL.
+func New(cfg notifier.Config) (notifier.Notifier, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("missing mattermost url")
+	}
+	if cfg.Token == "" {
+		return nil, errors.New("missing mattermost bot token")
+	}
+
+	return &mattermost{
+		baseURL: strings.TrimSuffix(cfg.URL, "/") + "/api/v4",
+		token:   cfg.Token,
+		channel: cfg.Channel,
+		http:    &http.Client{Timeout: httpTimeout},
+	}, nil
+}
+
+type post struct {
+	ID        string `json:"id,omitempty"`
+	ChannelID string `json:"channel_id"`
+	Message   string `json:"message"`
+}
+
+type object struct {
+	ID string `json:"id"`
+}
+
+// Send posts the message to the channel.
+func (m *mattermost) Send(msg notifier.Message) error {
+	_, err := m.Post(msg)
+	return err
+}
+
+// Post posts the message to the channel and returns the id of the post.
+func (m *mattermost) Post(msg notifier.Message) (string, error) {
+	channelID, err := m.channelByName()
+	if err != nil {
+		return "", err
+	}
+	return m.createPost(channelID, msg)
+}
+
+// Update replaces the message of the post with the given id.
+func (m *mattermost) Update(id string, msg notifier.Message) error {
+	payload := struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+	}{id, msg.Text}
+
+	if err := m.do(http.MethodPut, "/posts/"+url.PathEscape(id), payload, nil); err != nil {
+		return fmt.Errorf("failed updating mattermost post: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the post with the given id.
+func (m *mattermost) Delete(id string) error {
+	if err := m.do(http.MethodDelete, "/posts/"+url.PathEscape(id), nil, nil); err != nil {
+		return fmt.Errorf("failed deleting mattermost post: %w", err)
+	}
+	return nil
+}
+
+// SendDirect posts the message to the direct message channel of the bot and the user (e.g. @john).
+func (m *mattermost) SendDirect(user string, msg notifier.Message) error {
+	botUserID, err := m.botUser()
+	if err != nil {
+		return err
+	}
+
+	var u object
+	if err := m.do(http.MethodGet, "/users/username/"+url.PathEscape(strings.TrimPrefix(user, "@")), nil, &u); err != nil {
+		return fmt.Errorf("failed loading mattermost user %v: %w", user, err)
+	}
+
+	var channel object
+	if err := m.do(http.MethodPost, "/channels/direct", []string{botUserID, u.ID}, &channel); err != nil {
+		return fmt.Errorf("failed creating mattermost direct channel with %v: %w", user, err)
+	}
+
+	_, err = m.createPost(channel.ID, msg)
+	return err
+}
+
+// createPost posts the message to the channel and returns the id of the post.
+func (m *mattermost) createPost(channelID string, msg notifier.Message) (string, error) {
+	var created post
+	if err := m.do(http.MethodPost, "/posts", post{ChannelID: channelID, Message: msg.Text}, &created); err != nil {
+		return "", fmt.Errorf("failed creating mattermost post: %w", err)
+	}
+	return created.ID, nil
+}
+
+// botUser returns the user id of the token owner.
+func (m *mattermost) botUser() (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.botUserID != "" {
+		return m.botUserID, nil
+	}
+
+	var me object
+	if err := m.do(http.MethodGet, "/users/me", nil, &me); err != nil {
+		return "", fmt.Errorf("failed loading mattermost bot user: %w", err)
+	}
+	m.botUserID = me.ID
+	return me.ID, nil
+}
+
+// channelByName returns the id of the configured channel,
+// names in the format 'team/channel' are resolved.
+func (m *mattermost) channelByName() (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.channel == "" {
+		return "", errors.New("missing mattermost channel")
+	}
+	if m.channelID != "" {
+		return m.channelID, nil
+	}
+
+	team, name, ok := strings.Cut(m.channel, "/")
+	if !ok {
+		m.channelID = m.channel
+		return m.channelID, nil
+	}
+
+	var channel object
+	if err := m.do(http.MethodGet, fmt.Sprintf("/teams/name/%s/channels/name/%s", url.PathEscape(team), url.PathEscape(name)), nil, &channel); err != nil {
+		return "", fmt.Errorf("failed loading mattermost channel %v: %w", m.channel, err)
+	}
+	m.channelID = channel.ID
+	return m.channelID, nil
+}
+
+// do sends the payload to the API path and decodes the response into v.
+func (m *mattermost) do(method, path string, payload, v interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal payload: %w", err)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, m.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+m.token)
+
+	resp, err := m.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("failed to close mattermost response body: %v\n", err)
+		}
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status code: %v; body: %v", resp.StatusCode, string(body))
+	}
+
+	if v == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
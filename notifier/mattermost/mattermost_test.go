@@ -0,0 +1,120 @@
+package mattermost
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sj14/review-bot/notifier"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(notifier.Config{Token: "secret"})
+	require.Error(t, err)
+
+	_, err = New(notifier.Config{URL: "https://mattermost.example.com"})
+	require.Error(t, err)
+
+	n, err := New(notifier.Config{URL: "https://mattermost.example.com/", Token: "secret"})
+	require.NoError(t, err)
+	require.Equal(t, "https://mattermost.example.com/api/v4", n.(*mattermost).baseURL)
+}
+
+func TestSend(t *testing.T) {
+	var (
+		posts        []post
+		channelCalls int
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/users/me", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"id":"bot"}`)
+	})
+	mux.HandleFunc("GET /api/v4/users/username/hulk", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"hulk-id"}`)
+	})
+	mux.HandleFunc("GET /api/v4/teams/name/avengers/channels/name/backend", func(w http.ResponseWriter, r *http.Request) {
+		channelCalls++
+		fmt.Fprint(w, `{"id":"backend-id"}`)
+	})
+	mux.HandleFunc("POST /api/v4/channels/direct", func(w http.ResponseWriter, r *http.Request) {
+		var ids []string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&ids))
+		require.Equal(t, []string{"bot", "hulk-id"}, ids)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"direct-id"}`)
+	})
+	mux.HandleFunc("POST /api/v4/posts", func(w http.ResponseWriter, r *http.Request) {
+		var p post
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+		posts = append(posts, p)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"post-id"}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	n, err := New(notifier.Config{URL: srv.URL, Token: "secret", Channel: "avengers/backend"})
+	require.NoError(t, err)
+
+	require.NoError(t, n.Send(notifier.Message{Text: "reminder"}))
+	require.NoError(t, n.Send(notifier.Message{Text: "reminder"}))
+	require.Equal(t, 1, channelCalls, "the channel is resolved once")
+
+	require.NoError(t, n.(notifier.DirectMessenger).SendDirect("@hulk", notifier.Message{Text: "digest"}))
+	require.Error(t, n.(notifier.DirectMessenger).SendDirect("@unknown", notifier.Message{Text: "digest"}))
+
+	require.Equal(t, []post{
+		{ChannelID: "backend-id", Message: "reminder"},
+		{ChannelID: "backend-id", Message: "reminder"},
+		{ChannelID: "direct-id", Message: "digest"},
+	}, posts)
+}
+
+func TestEdit(t *testing.T) {
+	var requests []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		switch r.Method {
+		case http.MethodPost:
+			fmt.Fprint(w, `{"id":"post-id"}`)
+		case http.MethodPut:
+			var p post
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+			require.Equal(t, post{ID: "post-id", Message: "updated"}, p)
+			fmt.Fprint(w, `{"id":"post-id"}`)
+		case http.MethodDelete:
+			if r.URL.Path == "/api/v4/posts/unknown" {
+				http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+				return
+			}
+			fmt.Fprint(w, `{"status":"OK"}`)
+		}
+	}))
+	defer srv.Close()
+
+	n, err := New(notifier.Config{URL: srv.URL, Token: "secret", Channel: "backend-id"})
+	require.NoError(t, err)
+	ed := n.(notifier.Editor)
+
+	id, err := ed.Post(notifier.Message{Text: "reminder"})
+	require.NoError(t, err)
+	require.Equal(t, "post-id", id)
+	require.NoError(t, ed.Update(id, notifier.Message{Text: "updated"}))
+	require.NoError(t, ed.Delete(id))
+	require.Error(t, ed.Delete("unknown"))
+
+	require.Equal(t, []string{
+		"POST /api/v4/posts",
+		"PUT /api/v4/posts/post-id",
+		"DELETE /api/v4/posts/post-id",
+		"DELETE /api/v4/posts/unknown",
+	}, requests)
+}
@@ -0,0 +1,88 @@
+package notifier
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sj14/review-bot/hoster"
+	"github.com/sj14/review-bot/options"
+)
+
+// Notifier sends the reminder to a chat.
+type Notifier interface {
+	Send(msg Message) error
+}
+
+// DirectMessenger is implemented by notifiers which can send direct messages to users.
+type DirectMessenger interface {
+	// SendDirect sends the message to the user with the given chat handle (e.g. @john).
+	SendDirect(user string, msg Message) error
+}
+
+// Editor is implemented by notifiers which can change their previous messages.
+type Editor interface {
+	// Post sends the message to the channel and returns its id.
+	Post(msg Message) (string, error)
+	// Update replaces the message with the given id.
+	Update(id string, msg Message) error
+	// Delete removes the message with the given id.
+	Delete(id string) error
+}
+
+// Message is the reminder to send.
+type Message struct {
+	// Text is the executed template.
+	Text string
+	// Projects the text was executed from,
+	// notifiers with a structured format render them on their own.
+	Projects []hoster.Project
+}
+
+// Config contains the settings required to connect to a chat.
+type Config struct {
+	// Webhook URL of incoming webhook based notifiers.
+	Webhook string
+	// URL of the chat server (e.g. https://mattermost.example.com).
+	URL string
+	// Token of the bot user.
+	Token string
+	// Channel to post the reminder to.
+	Channel string
+	// Options are notifier specific settings.
+	Options options.Options
+	// Emails are the email addresses of the reviewers by chat handle.
+	Emails map[string]string
+}
+
+// Factory creates a new notifier from the given config.
+type Factory func(cfg Config) (Notifier, error)
+
+var factories = map[string]Factory{}
+
+// Register makes a notifier available by the given name.
+// It's meant to be called from the init function of the notifier package.
+func Register(name string, factory Factory) {
+	if _, ok := factories[name]; ok {
+		panic(fmt.Sprintf("notifier %q registered twice", name))
+	}
+	factories[name] = factory
+}
+
+// New creates the notifier registered with the given name.
+func New(name string, cfg Config) (Notifier, error) {
+	factory, ok := factories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown notifier %q (available: %v)", name, Names())
+	}
+	return factory(cfg)
+}
+
+// Names returns the names of all registered notifiers.
+func Names() []string {
+	var names []string
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,21 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	Register("mocked", func(cfg Config) (Notifier, error) { return nil, nil })
+
+	_, err := New("mocked", Config{})
+	require.NoError(t, err)
+
+	_, err = New("unknown", Config{})
+	require.Error(t, err)
+
+	require.Panics(t, func() {
+		Register("mocked", func(cfg Config) (Notifier, error) { return nil, nil })
+	})
+}
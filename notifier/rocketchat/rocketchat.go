@@ -0,0 +1,94 @@
+package rocketchat
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/sj14/review-bot/notifier"
+	"github.com/sj14/review-bot/notifier/markdown"
+)
+
+const httpTimeout = 15 * time.Second
+
+func init() {
+	notifier.Register("rocketchat", New)
+}
+
+// rocketchat implements notifier.Notifier using a Rocket.Chat incoming integration.
+type rocketchat struct {
+	webhook string
+	channel string
+	http    *http.Client
+}
+
+// New returns a notifier posting to the incoming integration.
+// The channel (e.g. #backend or @user) overrides the channel of the integration.
+func New(cfg notifier.Config) (notifier.Notifier, error) {
+	if cfg.Webhook == "" {
+		return nil, errors.New("missing rocketchat webhook")
+	}
+
+	return &rocketchat{
+		webhook: cfg.Webhook,
+		channel: cfg.Channel,
+		http:    &http.Client{Timeout: httpTimeout},
+	}, nil
+}
+
+// Send posts the text of the message converted to the Rocket.Chat markup.
+// Reviewers are mentioned with their username (e.g. @john.doe), as in the templates.
+func (r *rocketchat) Send(msg notifier.Message) error {
+	payload := struct {
+		Text    string `json:"text"`
+		Channel string `json:"channel,omitempty"`
+	}{markdown.ToMrkdwn(msg.Text), r.channel}
+
+	if err := r.post(payload); err != nil {
+		return fmt.Errorf("failed posting rocketchat message: %w", err)
+	}
+	return nil
+}
+
+func (r *rocketchat) post(payload interface{}) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	resp, err := r.http.Post(r.webhook, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("failed to close rocketchat response body: %v\n", err)
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status code: %v; body: %v", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Success bool   `json:"success"`
+		Error   string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if !result.Success {
+		return fmt.Errorf("rocketchat: %v", result.Error)
+	}
+	return nil
+}
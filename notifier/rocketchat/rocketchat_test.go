@@ -0,0 +1,47 @@
+package rocketchat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sj14/review-bot/notifier"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend(t *testing.T) {
+	_, err := New(notifier.Config{})
+	require.Error(t, err)
+
+	var payload map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload = nil
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		if payload["channel"] == "#unknown" {
+			fmt.Fprint(w, `{"success":false,"error":"invalid-channel"}`)
+			return
+		}
+		fmt.Fprint(w, `{"success":true}`)
+	}))
+	defer srv.Close()
+
+	n, err := New(notifier.Config{Webhook: srv.URL, Channel: "#backend"})
+	require.NoError(t, err)
+	require.NoError(t, n.Send(notifier.Message{Text: "# [repo](https://example.com/repo)\n\n**[PR](https://example.com/1)** @hulk"}))
+	require.Equal(t, map[string]string{
+		"channel": "#backend",
+		"text":    "*<https://example.com/repo|repo>*\n\n*<https://example.com/1|PR>* @hulk",
+	}, payload)
+
+	// the channel of the integration
+	n, err = New(notifier.Config{Webhook: srv.URL})
+	require.NoError(t, err)
+	require.NoError(t, n.Send(notifier.Message{Text: "reminder"}))
+	require.Equal(t, map[string]string{"text": "reminder"}, payload)
+
+	n, err = New(notifier.Config{Webhook: srv.URL, Channel: "#unknown"})
+	require.NoError(t, err)
+	require.ErrorContains(t, n.Send(notifier.Message{Text: "reminder"}), "invalid-channel")
+}
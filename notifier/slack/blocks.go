@@ -0,0 +1,146 @@
+package slack
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sj14/review-bot/hoster"
+)
+
+// maxBlocks is the maximum number of blocks of a single message.
+const maxBlocks = 50
+
+type block struct {
+	Type      string   `json:"type"`
+	Text      *text    `json:"text,omitempty"`
+	Accessory *element `json:"accessory,omitempty"`
+	Elements  []text   `json:"elements,omitempty"`
+
+	reminder bool // section of a single reminder
+}
+
+type text struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type element struct {
+	Type     string `json:"type"`
+	Text     text   `json:"text"`
+	URL      string `json:"url"`
+	ActionID string `json:"action_id"`
+}
+
+func mrkdwn(s string) *text {
+	return &text{Type: "mrkdwn", Text: s}
+}
+
+// renderBlocks renders the reminders as Block Kit sections,
+// a section per repository followed by a section with a review button per reminder.
+// Reminders exceeding the block limit of a message are summarized.
+func renderBlocks(projects []hoster.Project, now time.Time) []block {
+	var blocks []block
+
+	for i, p := range projects {
+		if i > 0 {
+			blocks = append(blocks, block{Type: "divider"})
+		}
+		blocks = append(blocks, block{Type: "section", Text: mrkdwn(link(p.Repository.URL, p.Repository.Name))})
+
+		for _, r := range p.Reminders {
+			b := block{Type: "section", Text: mrkdwn(reminderText(r, now)), reminder: true}
+			if r.URL != "" {
+				b.Accessory = &element{
+					Type:     "button",
+					Text:     text{Type: "plain_text", Text: "Review"},
+					URL:      r.URL,
+					ActionID: fmt.Sprintf("review-%d-%d", i, r.Number),
+				}
+			}
+			blocks = append(blocks, b)
+		}
+	}
+
+	if len(blocks) <= maxBlocks {
+		return blocks
+	}
+
+	// keep space for the summary
+	skipped := 0
+	for _, b := range blocks[maxBlocks-1:] {
+		if b.reminder {
+			skipped++
+		}
+	}
+	return append(blocks[:maxBlocks-1], block{
+		Type:     "context",
+		Elements: []text{{Type: "mrkdwn", Text: fmt.Sprintf("… and %d more", skipped)}},
+	})
+}
+
+// reminderText returns the title link, the age and the mentions of the missing reviewers.
+func reminderText(r hoster.Reminder, now time.Time) string {
+	details := []string{link(r.URL, r.Title)}
+
+	var info []string
+	if !r.CreatedAt.IsZero() {
+		info = append(info, "opened "+age(r.CreatedAt, now))
+	}
+	if r.Discussions > 0 {
+		info = append(info, fmt.Sprintf("%d 💬", r.Discussions))
+	}
+	if len(info) > 0 {
+		details = append(details, strings.Join(info, " · "))
+	}
+
+	if len(r.Missing) == 0 {
+		details = append(details, "You got all reviews, "+mention(r.Owner)+".")
+	} else {
+		var mentions []string
+		for _, m := range r.Missing {
+			mentions = append(mentions, mention(m))
+		}
+		details = append(details, strings.Join(mentions, " "))
+	}
+
+	return strings.Join(details, "\n")
+}
+
+// age returns the humanized duration since the given time.
+func age(t, now time.Time) string {
+	days := int(now.Sub(t).Hours() / 24)
+	switch {
+	case days <= 0:
+		return "today"
+	case days == 1:
+		return "1 day ago"
+	default:
+		return fmt.Sprintf("%d days ago", days)
+	}
+}
+
+var userID = regexp.MustCompile(`^@?([UW][A-Z0-9]{2,})$`)
+
+// mention returns a real mention for Slack user ids (e.g. @U024BE7LH),
+// other handles are kept as they are.
+func mention(handle string) string {
+	if m := userID.FindStringSubmatch(handle); m != nil {
+		return "<@" + m[1] + ">"
+	}
+	return escape(handle)
+}
+
+// link returns a mrkdwn link, or only the text without an URL.
+func link(url, title string) string {
+	if url == "" {
+		return "*" + escape(title) + "*"
+	}
+	return "*<" + url + "|" + escape(title) + ">*"
+}
+
+// escape replaces the control characters of mrkdwn.
+func escape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
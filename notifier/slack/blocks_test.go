@@ -0,0 +1,57 @@
+package slack
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sj14/review-bot/hoster"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderBlocks(t *testing.T) {
+	now := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	projects := []hoster.Project{
+		{Repository: hoster.Repository{Name: "repo0", URL: "https://example.com/repo0"}, Reminders: []hoster.Reminder{
+			{Number: 1, Title: "Fix <script> & more", URL: "https://example.com/repo0/1", CreatedAt: now.Add(-72 * time.Hour), Discussions: 2, Missing: []string{"@U024BE7LH", "W0G9QF9C6", "@hulk"}},
+		}},
+		{Repository: hoster.Repository{Name: "repo1"}, Reminders: []hoster.Reminder{
+			{Number: 2, Title: "PR2", Owner: "@U0JA38A"},
+		}},
+	}
+
+	want := []block{
+		{Type: "section", Text: mrkdwn("*<https://example.com/repo0|repo0>*")},
+		{
+			Type:      "section",
+			Text:      mrkdwn("*<https://example.com/repo0/1|Fix &lt;script&gt; &amp; more>*\nopened 3 days ago · 2 💬\n<@U024BE7LH> <@W0G9QF9C6> @hulk"),
+			Accessory: &element{Type: "button", Text: text{Type: "plain_text", Text: "Review"}, URL: "https://example.com/repo0/1", ActionID: "review-0-1"},
+			reminder:  true,
+		},
+		{Type: "divider"},
+		{Type: "section", Text: mrkdwn("*repo1*")},
+		{Type: "section", Text: mrkdwn("*PR2*\nYou got all reviews, <@U0JA38A>."), reminder: true},
+	}
+
+	require.Equal(t, want, renderBlocks(projects, now))
+}
+
+func TestRenderBlocksLimit(t *testing.T) {
+	var reminders []hoster.Reminder
+	for i := range 60 {
+		reminders = append(reminders, hoster.Reminder{Number: i, Title: fmt.Sprint(i)})
+	}
+
+	blocks := renderBlocks([]hoster.Project{{Repository: hoster.Repository{Name: "repo"}, Reminders: reminders}}, time.Now())
+	require.Len(t, blocks, maxBlocks)
+	// 1 repository and 48 reminders fit
+	require.Equal(t, block{Type: "context", Elements: []text{{Type: "mrkdwn", Text: "… and 12 more"}}}, blocks[maxBlocks-1])
+}
+
+func TestAge(t *testing.T) {
+	now := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+	require.Equal(t, "today", age(now.Add(-time.Hour), now))
+	require.Equal(t, "1 day ago", age(now.Add(-30*time.Hour), now))
+	require.Equal(t, "5 days ago", age(now.Add(-5*24*time.Hour), now))
+}
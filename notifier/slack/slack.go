@@ -0,0 +1,199 @@
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sj14/review-bot/notifier"
+)
+
+const (
+	httpTimeout = 15 * time.Second
+	defaultURL  = "https://slack.com/api"
+)
+
+func init() {
+	notifier.Register("slack", New)
+}
+
+// slack implements notifier.Notifier using the Slack Web API.
+type slack struct {
+	baseURL string
+	token   string
+	channel string
+	blocks  bool
+	http    *http.Client
+	now     func() time.Time
+}
+
+// New returns a notifier posting with the bot token to the channel (e.g. C024BE91L).
+// The URL of the Web API defaults to https://slack.com/api.
+// The reminders are rendered as Block Kit sections, unless the option 'blocks=false' is set.
+func New(cfg notifier.Config) (notifier.Notifier, error) {
+	if cfg.Token == "" {
+		return nil, errors.New("missing slack bot token")
+	}
+	if err := cfg.Options.Check("blocks"); err != nil {
+		return nil, err
+	}
+	blocks, err := cfg.Options.Bool("blocks", true)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := cfg.URL
+	if baseURL == "" {
+		baseURL = defaultURL
+	}
+
+	return &slack{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   cfg.Token,
+		channel: cfg.Channel,
+		blocks:  blocks,
+		http:    &http.Client{Timeout: httpTimeout},
+		now:     time.Now,
+	}, nil
+}
+
+// Send posts the message to the channel.
+func (s *slack) Send(msg notifier.Message) error {
+	_, err := s.Post(msg)
+	return err
+}
+
+// Post posts the message to the channel and returns its timestamp.
+func (s *slack) Post(msg notifier.Message) (string, error) {
+	if s.channel == "" {
+		return "", errors.New("missing slack channel")
+	}
+
+	return s.postMessage(s.channel, msg.Text, s.render(msg))
+}
+
+// Update replaces the message with the given timestamp.
+// Without blocks, an empty list removes the blocks of the previous message.
+func (s *slack) Update(ts string, msg notifier.Message) error {
+	blocks := s.render(msg)
+	if blocks == nil {
+		blocks = []block{}
+	}
+
+	payload := struct {
+		Channel string  `json:"channel"`
+		TS      string  `json:"ts"`
+		Text    string  `json:"text"`
+		Blocks  []block `json:"blocks"`
+	}{s.channel, ts, msg.Text, blocks}
+
+	if err := s.call("chat.update", payload, nil); err != nil {
+		return fmt.Errorf("failed updating slack message: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the message with the given timestamp.
+func (s *slack) Delete(ts string) error {
+	payload := struct {
+		Channel string `json:"channel"`
+		TS      string `json:"ts"`
+	}{s.channel, ts}
+
+	if err := s.call("chat.delete", payload, nil); err != nil {
+		return fmt.Errorf("failed deleting slack message: %w", err)
+	}
+	return nil
+}
+
+// render returns the blocks of the message, or nil when blocks are disabled.
+func (s *slack) render(msg notifier.Message) []block {
+	if !s.blocks {
+		return nil
+	}
+	return renderBlocks(msg.Projects, s.now())
+}
+
+// SendDirect posts the text of the message to the direct message channel of the user id (e.g. @U024BE7LH).
+func (s *slack) SendDirect(user string, msg notifier.Message) error {
+	_, err := s.postMessage(strings.TrimPrefix(user, "@"), msg.Text, nil)
+	return err
+}
+
+// postMessage posts the text, or the blocks with the text as notification fallback,
+// and returns the timestamp of the message.
+func (s *slack) postMessage(channel, text string, blocks []block) (string, error) {
+	payload := struct {
+		Channel string  `json:"channel"`
+		Text    string  `json:"text"`
+		Blocks  []block `json:"blocks,omitempty"`
+	}{channel, text, blocks}
+
+	var resp struct {
+		TS string `json:"ts"`
+	}
+	if err := s.call("chat.postMessage", payload, &resp); err != nil {
+		return "", fmt.Errorf("failed posting slack message: %w", err)
+	}
+	return resp.TS, nil
+}
+
+// call posts the payload to the Web API method and decodes the response into v.
+func (s *slack) call(method string, payload, v interface{}) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.baseURL+"/"+method, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+s.token)
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("failed to close slack response body: %v\n", err)
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status code: %v; body: %v", resp.StatusCode, string(body))
+	}
+
+	// the Web API reports errors with status code 200
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if !result.OK {
+		return fmt.Errorf("%v: %v", method, result.Error)
+	}
+
+	if v == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sj14/review-bot/hoster"
+	"github.com/sj14/review-bot/notifier"
+	"github.com/sj14/review-bot/options"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(notifier.Config{})
+	require.Error(t, err)
+
+	n, err := New(notifier.Config{Token: "xoxb-secret"})
+	require.NoError(t, err)
+	require.Equal(t, defaultURL, n.(*slack).baseURL)
+}
+
+func TestSend(t *testing.T) {
+	var posted []map[string]string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/chat.postMessage", r.URL.Path)
+		require.Equal(t, "Bearer xoxb-secret", r.Header.Get("Authorization"))
+
+		var payload map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		posted = append(posted, payload)
+
+		if payload["channel"] == "unknown" {
+			fmt.Fprint(w, `{"ok":false,"error":"channel_not_found"}`)
+			return
+		}
+		fmt.Fprint(w, `{"ok":true,"channel":"C024BE91L","ts":"1503435956.000247"}`)
+	}))
+	defer srv.Close()
+
+	n, err := New(notifier.Config{URL: srv.URL, Token: "xoxb-secret", Channel: "C024BE91L"})
+	require.NoError(t, err)
+
+	require.NoError(t, n.Send(notifier.Message{Text: "reminder"}))
+	require.NoError(t, n.(notifier.DirectMessenger).SendDirect("@U024BE7LH", notifier.Message{Text: "digest"}))
+	require.Equal(t, []map[string]string{
+		{"channel": "C024BE91L", "text": "reminder"},
+		{"channel": "U024BE7LH", "text": "digest"},
+	}, posted)
+
+	err = n.(notifier.DirectMessenger).SendDirect("unknown", notifier.Message{Text: "digest"})
+	require.ErrorContains(t, err, "channel_not_found")
+
+	n, err = New(notifier.Config{URL: srv.URL, Token: "xoxb-secret"})
+	require.NoError(t, err)
+	require.Error(t, n.Send(notifier.Message{Text: "reminder"}), "missing channel")
+}
+
+func TestPostBlocks(t *testing.T) {
+	var payload struct {
+		Channel string  `json:"channel"`
+		Text    string  `json:"text"`
+		Blocks  []block `json:"blocks"`
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload.Blocks = nil
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		fmt.Fprint(w, `{"ok":true,"channel":"C024BE91L","ts":"1503435956.000247"}`)
+	}))
+	defer srv.Close()
+
+	msg := notifier.Message{
+		Text: "fallback",
+		Projects: []hoster.Project{{
+			Repository: hoster.Repository{Name: "repo"},
+			Reminders:  []hoster.Reminder{{Number: 1, Title: "PR", URL: "https://example.com/1", Missing: []string{"@U024BE7LH"}}},
+		}},
+	}
+
+	n, err := New(notifier.Config{URL: srv.URL, Token: "xoxb-secret", Channel: "C024BE91L"})
+	require.NoError(t, err)
+
+	ts, err := n.(*slack).Post(msg)
+	require.NoError(t, err)
+	require.Equal(t, "1503435956.000247", ts)
+	require.Equal(t, "fallback", payload.Text)
+	require.Len(t, payload.Blocks, 2)
+	require.Equal(t, "*<https://example.com/1|PR>*\n<@U024BE7LH>", payload.Blocks[1].Text.Text)
+	require.Equal(t, "https://example.com/1", payload.Blocks[1].Accessory.URL)
+
+	// plain text only
+	n, err = New(notifier.Config{URL: srv.URL, Token: "xoxb-secret", Channel: "C024BE91L", Options: options.Options{"blocks": "false"}})
+	require.NoError(t, err)
+	require.NoError(t, n.Send(msg))
+	require.Empty(t, payload.Blocks)
+
+	_, err = New(notifier.Config{Token: "xoxb-secret", Options: options.Options{"unknown": "true"}})
+	require.Error(t, err)
+}
+
+func TestEdit(t *testing.T) {
+	type call struct {
+		Method string
+		TS     string
+		Text   string
+	}
+	var calls []call
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Channel string `json:"channel"`
+			TS      string `json:"ts"`
+			Text    string `json:"text"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		require.Equal(t, "C024BE91L", payload.Channel)
+		calls = append(calls, call{r.URL.Path, payload.TS, payload.Text})
+
+		if payload.TS == "unknown" {
+			fmt.Fprint(w, `{"ok":false,"error":"message_not_found"}`)
+			return
+		}
+		fmt.Fprint(w, `{"ok":true,"channel":"C024BE91L","ts":"1503435956.000247"}`)
+	}))
+	defer srv.Close()
+
+	n, err := New(notifier.Config{URL: srv.URL, Token: "xoxb-secret", Channel: "C024BE91L"})
+	require.NoError(t, err)
+	ed := n.(notifier.Editor)
+
+	require.NoError(t, ed.Update("1503435956.000247", notifier.Message{Text: "updated"}))
+	require.NoError(t, ed.Delete("1503435956.000247"))
+	require.ErrorContains(t, ed.Update("unknown", notifier.Message{Text: "updated"}), "message_not_found")
+
+	require.Equal(t, []call{
+		{"/chat.update", "1503435956.000247", "updated"},
+		{"/chat.delete", "1503435956.000247", ""},
+		{"/chat.update", "unknown", "updated"},
+	}, calls)
+}
+
+func TestUpdateWithoutBlocks(t *testing.T) {
+	var body map[string]json.RawMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		fmt.Fprint(w, `{"ok":true,"channel":"C024BE91L","ts":"1503435956.000247"}`)
+	}))
+	defer srv.Close()
+
+	n, err := New(notifier.Config{URL: srv.URL, Token: "xoxb-secret", Channel: "C024BE91L", Options: options.Options{"blocks": "false"}})
+	require.NoError(t, err)
+
+	require.NoError(t, n.(notifier.Editor).Update("1503435956.000247", notifier.Message{Text: "updated", Projects: []hoster.Project{{
+		Repository: hoster.Repository{Name: "repo"},
+		Reminders:  []hoster.Reminder{{Title: "PR"}},
+	}}}))
+	require.JSONEq(t, `[]`, string(body["blocks"]), "removes the blocks of the previous message")
+	require.JSONEq(t, `"updated"`, string(body["text"]))
+}
@@ -0,0 +1,258 @@
+package smtp
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	netsmtp "net/smtp"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sj14/review-bot/notifier"
+	"github.com/sj14/review-bot/notifier/markdown"
+)
+
+const (
+	timeout        = 30 * time.Second
+	defaultSubject = "Review reminder"
+)
+
+func init() {
+	notifier.Register("smtp", New)
+}
+
+// mailer implements notifier.Notifier by sending emails over SMTP.
+type mailer struct {
+	addr        string // host:port
+	host        string
+	implicitTLS bool
+	insecure    bool // allows smtp:// without STARTTLS
+	tlsConfig   *tls.Config
+	username    string
+	password    string
+	from        *mail.Address
+	to          []string
+	subject     string
+	emails      map[string]string
+	now         func() time.Time
+}
+
+// New returns a notifier sending emails with the server at the URL
+// (smtp://host:587 with STARTTLS or smtps://host:465 with implicit TLS) to the
+// comma separated recipients of the channel. The token is the password of the user,
+// the 'from' address is set with the options, as well as the 'username' (defaults to from)
+// and the 'subject'. Sending over smtp:// fails when the server doesn't support STARTTLS,
+// unless the 'insecure' option is set.
+func New(cfg notifier.Config) (notifier.Notifier, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("missing smtp url")
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse smtp url: %w", err)
+	}
+
+	var implicitTLS bool
+	port := u.Port()
+	switch u.Scheme {
+	case "smtp":
+		if port == "" {
+			port = "587"
+		}
+	case "smtps":
+		implicitTLS = true
+		if port == "" {
+			port = "465"
+		}
+	default:
+		return nil, fmt.Errorf("invalid smtp url scheme %q (options: smtp, smtps)", u.Scheme)
+	}
+
+	if err := cfg.Options.Check("from", "username", "subject", "insecure"); err != nil {
+		return nil, err
+	}
+	insecure, err := cfg.Options.Bool("insecure", false)
+	if err != nil {
+		return nil, err
+	}
+	from, err := mail.ParseAddress(cfg.Options.String("from", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address: %w", err)
+	}
+
+	var to []string
+	for _, rcpt := range strings.Split(cfg.Channel, ",") {
+		if rcpt = strings.TrimSpace(rcpt); rcpt == "" {
+			continue
+		}
+		addr, err := mail.ParseAddress(rcpt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient %q: %w", rcpt, err)
+		}
+		to = append(to, addr.Address)
+	}
+
+	return &mailer{
+		addr:        net.JoinHostPort(u.Hostname(), port),
+		host:        u.Hostname(),
+		implicitTLS: implicitTLS,
+		insecure:    insecure,
+		tlsConfig:   &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12},
+		username:    cfg.Options.String("username", from.Address),
+		password:    cfg.Token,
+		from:        from,
+		to:          to,
+		subject:     cfg.Options.String("subject", defaultSubject),
+		emails:      cfg.Emails,
+		now:         time.Now,
+	}, nil
+}
+
+// Send sends the message to the recipients.
+func (m *mailer) Send(msg notifier.Message) error {
+	if len(m.to) == 0 {
+		return errors.New("missing email recipients")
+	}
+	return m.send(m.to, msg.Text)
+}
+
+// SendDirect sends the message to the email address of the reviewer,
+// either from the reviewer mapping or the chat handle itself.
+func (m *mailer) SendDirect(user string, msg notifier.Message) error {
+	address, ok := m.emails[user]
+	if !ok {
+		addr, err := mail.ParseAddress(user)
+		if err != nil {
+			return fmt.Errorf("missing email address of %v", user)
+		}
+		address = addr.Address
+	}
+	return m.send([]string{address}, msg.Text)
+}
+
+// send sends the text as multipart email with a plain text and HTML part.
+func (m *mailer) send(to []string, text string) error {
+	data, err := m.compose(to, text)
+	if err != nil {
+		return err
+	}
+
+	conn, err := m.dial()
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return fmt.Errorf("failed to set deadline: %w", err)
+	}
+
+	c, err := netsmtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to create smtp client: %w", err)
+	}
+	defer c.Close()
+
+	if !m.implicitTLS {
+		ok, _ := c.Extension("STARTTLS")
+		switch {
+		case ok:
+			if err := c.StartTLS(m.tlsConfig); err != nil {
+				return fmt.Errorf("failed to start tls: %w", err)
+			}
+		case !m.insecure:
+			return errors.New("smtp server doesn't support STARTTLS (set the 'insecure' option to send without TLS)")
+		}
+	}
+
+	if m.password != "" {
+		// refuses to send the password without TLS, except to localhost
+		if err := c.Auth(netsmtp.PlainAuth("", m.username, m.password, m.host)); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	if err := c.Mail(m.from.Address); err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("failed to add recipient %v: %w", rcpt, err)
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start data: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return c.Quit()
+}
+
+func (m *mailer) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: timeout}
+	if m.implicitTLS {
+		return tls.DialWithDialer(dialer, "tcp", m.addr, m.tlsConfig)
+	}
+	return dialer.Dial("tcp", m.addr)
+}
+
+// compose returns the email with the text as plain and as HTML alternative.
+func (m *mailer) compose(to []string, text string) ([]byte, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+
+	parts := []struct{ contentType, content string }{
+		{"text/plain", text},
+		{"text/html", "<!DOCTYPE html>\n<html><body>" + markdown.ToHTML(text, nil) + "</body></html>"},
+	}
+	for _, p := range parts {
+		pw, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType + "; charset=utf-8"},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %v part: %w", p.contentType, err)
+		}
+		qp := quotedprintable.NewWriter(pw)
+		if _, err := qp.Write([]byte(p.content)); err != nil {
+			return nil, fmt.Errorf("failed to write %v part: %w", p.contentType, err)
+		}
+		if err := qp.Close(); err != nil {
+			return nil, fmt.Errorf("failed to write %v part: %w", p.contentType, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart: %w", err)
+	}
+
+	var msg bytes.Buffer
+	headers := []struct{ key, value string }{
+		{"From", m.from.String()},
+		{"To", strings.Join(to, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", m.subject)},
+		{"Date", m.now().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": w.Boundary()})},
+	}
+	for _, h := range headers {
+		fmt.Fprintf(&msg, "%s: %s\r\n", h.key, h.value)
+	}
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
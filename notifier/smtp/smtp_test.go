@@ -0,0 +1,288 @@
+package smtp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http/httptest"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sj14/review-bot/notifier"
+	"github.com/sj14/review-bot/options"
+	"github.com/stretchr/testify/require"
+)
+
+type received struct {
+	from string
+	to   []string
+	auth string
+	tls  bool
+	data string
+}
+
+// fakeServer is a minimal SMTP server supporting STARTTLS or implicit TLS and AUTH PLAIN.
+type fakeServer struct {
+	ln       net.Listener
+	tls      *tls.Config
+	implicit bool
+	plain    bool // doesn't advertise STARTTLS
+
+	mu    sync.Mutex
+	mails []received
+}
+
+func newFakeServer(t *testing.T, implicit, plain bool) (*fakeServer, *x509.CertPool) {
+	// reuse the certificate for 127.0.0.1 of the test server
+	ts := httptest.NewUnstartedServer(nil)
+	ts.StartTLS()
+	ts.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ts.Certificate())
+	tlsConfig := &tls.Config{Certificates: ts.TLS.Certificates}
+
+	var (
+		ln  net.Listener
+		err error
+	)
+	if implicit {
+		ln, err = tls.Listen("tcp", "127.0.0.1:0", tlsConfig)
+	} else {
+		ln, err = net.Listen("tcp", "127.0.0.1:0")
+	}
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	s := &fakeServer{ln: ln, tls: tlsConfig, implicit: implicit, plain: plain}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s, pool
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	mail := received{tls: s.implicit}
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 fake ESMTP")
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(cmd) {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250-fake")
+			if !mail.tls && !s.plain {
+				tp.PrintfLine("250-STARTTLS")
+			}
+			tp.PrintfLine("250 AUTH PLAIN")
+		case "STARTTLS":
+			tp.PrintfLine("220 ready")
+			tlsConn := tls.Server(conn, s.tls)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn, tp, mail.tls = tlsConn, textproto.NewConn(tlsConn), true
+		case "AUTH":
+			_, creds, _ := strings.Cut(arg, " ")
+			b, _ := base64.StdEncoding.DecodeString(creds)
+			mail.auth = string(b)
+			tp.PrintfLine("235 authenticated")
+		case "MAIL":
+			mail.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			tp.PrintfLine("250 ok")
+		case "RCPT":
+			rcpt := strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>")
+			if strings.HasPrefix(rcpt, "unknown@") {
+				tp.PrintfLine("550 no such user")
+				continue
+			}
+			mail.to = append(mail.to, rcpt)
+			tp.PrintfLine("250 ok")
+		case "DATA":
+			tp.PrintfLine("354 go ahead")
+			b, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			mail.data = string(b)
+			s.mu.Lock()
+			s.mails = append(s.mails, mail)
+			s.mu.Unlock()
+			tp.PrintfLine("250 queued")
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func (s *fakeServer) received() []received {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mails
+}
+
+// parts returns the content of the parts by content type.
+func parts(t *testing.T, data string) (*mail.Message, map[string]string) {
+	msg, err := mail.ReadMessage(strings.NewReader(data))
+	require.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	contents := map[string]string{}
+	r := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		p, err := r.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		b, err := io.ReadAll(p) // decodes quoted-printable
+		require.NoError(t, err)
+		contentType, _, _ := strings.Cut(p.Header.Get("Content-Type"), ";")
+		contents[contentType] = string(b)
+	}
+	return msg, contents
+}
+
+func TestNew(t *testing.T) {
+	tests := map[string]notifier.Config{
+		"missing url":       {Options: options.Options{"from": "bot@example.com"}},
+		"invalid scheme":    {URL: "https://mail.example.com", Options: options.Options{"from": "bot@example.com"}},
+		"missing from":      {URL: "smtp://mail.example.com"},
+		"invalid recipient": {URL: "smtp://mail.example.com", Channel: "nobody", Options: options.Options{"from": "bot@example.com"}},
+		"unknown option":    {URL: "smtp://mail.example.com", Options: options.Options{"from": "bot@example.com", "unknown": "1"}},
+		"invalid insecure":  {URL: "smtp://mail.example.com", Options: options.Options{"from": "bot@example.com", "insecure": "maybe"}},
+	}
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(cfg)
+			require.Error(t, err)
+		})
+	}
+
+	n, err := New(notifier.Config{URL: "smtp://mail.example.com", Channel: "a@example.com, Boss <b@example.com>", Options: options.Options{"from": "Review Bot <bot@example.com>"}})
+	require.NoError(t, err)
+	m := n.(*mailer)
+	require.Equal(t, "mail.example.com:587", m.addr)
+	require.False(t, m.implicitTLS)
+	require.Equal(t, []string{"a@example.com", "b@example.com"}, m.to)
+	require.Equal(t, "bot@example.com", m.username)
+
+	n, err = New(notifier.Config{URL: "smtps://mail.example.com", Options: options.Options{"from": "bot@example.com"}})
+	require.NoError(t, err)
+	require.Equal(t, "mail.example.com:465", n.(*mailer).addr)
+	require.True(t, n.(*mailer).implicitTLS)
+	require.Error(t, n.Send(notifier.Message{Text: "reminder"}), "missing recipients")
+}
+
+func TestSend(t *testing.T) {
+	for name, implicit := range map[string]bool{"starttls": false, "implicit tls": true} {
+		t.Run(name, func(t *testing.T) {
+			srv, pool := newFakeServer(t, implicit, false)
+
+			scheme := "smtp"
+			if implicit {
+				scheme = "smtps"
+			}
+			n, err := New(notifier.Config{
+				URL:     scheme + "://" + srv.ln.Addr().String(),
+				Token:   "secret",
+				Channel: "manager@example.com,lead@example.com",
+				Options: options.Options{"from": "Review Bot <bot@example.com>", "subject": "Offene Reviews 👀"},
+			})
+			require.NoError(t, err)
+			n.(*mailer).tlsConfig.RootCAs = pool
+
+			require.NoError(t, n.Send(notifier.Message{Text: "# [repo](https://example.com/repo)\n\n**PR** @hulk"}))
+
+			mails := srv.received()
+			require.Len(t, mails, 1)
+			require.True(t, mails[0].tls)
+			require.Equal(t, "\x00bot@example.com\x00secret", mails[0].auth)
+			require.Equal(t, "bot@example.com", mails[0].from)
+			require.Equal(t, []string{"manager@example.com", "lead@example.com"}, mails[0].to)
+
+			msg, contents := parts(t, mails[0].data)
+			require.Equal(t, `"Review Bot" <bot@example.com>`, msg.Header.Get("From"))
+			require.Equal(t, "manager@example.com, lead@example.com", msg.Header.Get("To"))
+			subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+			require.NoError(t, err)
+			require.Equal(t, "Offene Reviews 👀", subject)
+
+			require.Equal(t, "# [repo](https://example.com/repo)\n\n**PR** @hulk", contents["text/plain"])
+			require.Equal(t, "<!DOCTYPE html>\n<html><body><h1><a href=\"https://example.com/repo\">repo</a></h1><p><strong>PR</strong> @hulk</p></body></html>", contents["text/html"])
+		})
+	}
+}
+
+func TestSendWithoutSTARTTLS(t *testing.T) {
+	srv, _ := newFakeServer(t, false, true)
+
+	cfg := notifier.Config{
+		URL:     "smtp://" + srv.ln.Addr().String(),
+		Channel: "manager@example.com",
+		Options: options.Options{"from": "bot@example.com"},
+	}
+	n, err := New(cfg)
+	require.NoError(t, err)
+	require.ErrorContains(t, n.Send(notifier.Message{Text: "reminder"}), "doesn't support STARTTLS")
+	require.Empty(t, srv.received())
+
+	cfg.Options["insecure"] = "true"
+	n, err = New(cfg)
+	require.NoError(t, err)
+	require.NoError(t, n.Send(notifier.Message{Text: "reminder"}))
+
+	mails := srv.received()
+	require.Len(t, mails, 1)
+	require.False(t, mails[0].tls)
+}
+
+func TestSendDirect(t *testing.T) {
+	srv, pool := newFakeServer(t, false, false)
+
+	n, err := New(notifier.Config{
+		URL:     "smtp://" + srv.ln.Addr().String(),
+		Options: options.Options{"from": "bot@example.com"},
+		Emails:  map[string]string{"@hulk": "hulk@example.com", "@ghost": "unknown@example.com"},
+	})
+	require.NoError(t, err)
+	n.(*mailer).tlsConfig.RootCAs = pool
+	dm := n.(notifier.DirectMessenger)
+
+	require.NoError(t, dm.SendDirect("@hulk", notifier.Message{Text: "digest"}))
+	require.NoError(t, dm.SendDirect("groot@example.com", notifier.Message{Text: "digest"}))
+	require.ErrorContains(t, dm.SendDirect("@batman", notifier.Message{Text: "digest"}), "missing email address")
+	require.ErrorContains(t, dm.SendDirect("@ghost", notifier.Message{Text: "digest"}), "no such user")
+
+	mails := srv.received()
+	require.Len(t, mails, 2)
+	require.Equal(t, []string{"hulk@example.com"}, mails[0].to)
+	require.Empty(t, mails[0].auth, "no auth without password")
+	require.Equal(t, []string{"groot@example.com"}, mails[1].to)
+}
@@ -0,0 +1,77 @@
+package notifier
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// State remembers the ids of the previously posted messages in a JSON file.
+type State struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewState returns the state stored at the given path.
+// The file is created with the first message.
+func NewState(path string) *State {
+	return &State{path: path}
+}
+
+// Get returns the id of the previous message with the given key, or an empty string.
+func (s *State) Get(key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	return ids[key], nil
+}
+
+// Set stores the id of the message with the given key, an empty id removes the key.
+func (s *State) Set(key, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	if id == "" {
+		delete(ids, key)
+	} else {
+		ids[key] = id
+	}
+
+	b, err := json.MarshalIndent(ids, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+	if err := os.WriteFile(s.path, b, 0o600); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
+
+func (s *State) load() (map[string]string, error) {
+	ids := map[string]string{}
+
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ids, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	if err := json.Unmarshal(b, &ids); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal state: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,39 @@
+package notifier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	s := NewState(path)
+
+	id, err := s.Get("backend/slack/C024BE91L")
+	require.NoError(t, err)
+	require.Empty(t, id)
+
+	require.NoError(t, s.Set("backend/slack/C024BE91L", "1503435956.000247"))
+	require.NoError(t, s.Set("backend/mattermost/backend", "post-id"))
+
+	// a new instance reads the stored ids
+	id, err = NewState(path).Get("backend/slack/C024BE91L")
+	require.NoError(t, err)
+	require.Equal(t, "1503435956.000247", id)
+
+	require.NoError(t, s.Set("backend/slack/C024BE91L", ""))
+	id, err = s.Get("backend/slack/C024BE91L")
+	require.NoError(t, err)
+	require.Empty(t, id)
+
+	id, err = s.Get("backend/mattermost/backend")
+	require.NoError(t, err)
+	require.Equal(t, "post-id", id)
+
+	require.NoError(t, os.WriteFile(path, []byte("invalid"), 0o600))
+	_, err = s.Get("backend/slack/C024BE91L")
+	require.Error(t, err)
+}
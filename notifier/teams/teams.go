@@ -0,0 +1,162 @@
+package teams
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sj14/review-bot/notifier"
+)
+
+const httpTimeout = 15 * time.Second
+
+func init() {
+	notifier.Register("teams", New)
+}
+
+// teams implements notifier.Notifier using a Teams incoming webhook or Workflows URL.
+type teams struct {
+	webhook string
+	http    *http.Client
+}
+
+// New returns a notifier posting Adaptive Cards to the webhook.
+func New(cfg notifier.Config) (notifier.Notifier, error) {
+	if cfg.Webhook == "" {
+		return nil, errors.New("missing teams webhook")
+	}
+
+	return &teams{
+		webhook: cfg.Webhook,
+		http:    &http.Client{Timeout: httpTimeout},
+	}, nil
+}
+
+type message struct {
+	Type        string       `json:"type"`
+	Attachments []attachment `json:"attachments"`
+}
+
+type attachment struct {
+	ContentType string `json:"contentType"`
+	Content     card   `json:"content"`
+}
+
+type card struct {
+	Schema  string      `json:"$schema"`
+	Type    string      `json:"type"`
+	Version string      `json:"version"`
+	Body    []textBlock `json:"body"`
+	MSTeams msteams     `json:"msteams"`
+}
+
+type textBlock struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+	Wrap bool   `json:"wrap"`
+}
+
+type msteams struct {
+	Width    string    `json:"width"`
+	Entities []mention `json:"entities,omitempty"`
+}
+
+type mention struct {
+	Type      string    `json:"type"`
+	Text      string    `json:"text"`
+	Mentioned mentioned `json:"mentioned"`
+}
+
+type mentioned struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Send posts the text of the message as Adaptive Card to the webhook.
+func (t *teams) Send(msg notifier.Message) error {
+	if err := t.post(newMessage(msg.Text)); err != nil {
+		return fmt.Errorf("failed posting teams message: %w", err)
+	}
+	return nil
+}
+
+// newMessage returns the card with the text as markdown.
+func newMessage(text string) message {
+	text, entities := mentions(text)
+
+	c := card{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		Body:    []textBlock{{Type: "TextBlock", Text: text, Wrap: true}},
+		MSTeams: msteams{Width: "Full", Entities: entities},
+	}
+
+	return message{
+		Type:        "message",
+		Attachments: []attachment{{ContentType: "application/vnd.microsoft.card.adaptive", Content: c}},
+	}
+}
+
+var (
+	atTag = regexp.MustCompile(`<at>(.*?)</at>`)
+	// user principal names (e.g. john@example.com) and Entra object ids can be mentioned
+	userID = regexp.MustCompile(`^([^@\s]+@[^@\s]+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})$`)
+)
+
+// mentions returns the text with the <at> tags of users and the mention entities.
+// Tags of other handles (e.g. unmapped reviewers) are replaced by the plain handle.
+func mentions(text string) (string, []mention) {
+	var (
+		entities []mention
+		seen     = map[string]bool{}
+	)
+
+	text = atTag.ReplaceAllStringFunc(text, func(tag string) string {
+		handle := strings.TrimPrefix(atTag.FindStringSubmatch(tag)[1], "@")
+		if !userID.MatchString(handle) {
+			return handle
+		}
+
+		tag = "<at>" + handle + "</at>"
+		if !seen[handle] {
+			seen[handle] = true
+			entities = append(entities, mention{Type: "mention", Text: tag, Mentioned: mentioned{ID: handle, Name: handle}})
+		}
+		return tag
+	})
+
+	return text, entities
+}
+
+func (t *teams) post(payload message) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	resp, err := t.http.Post(t.webhook, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("failed to close teams response body: %v\n", err)
+		}
+	}()
+
+	// incoming webhooks respond with 200, workflows with 202
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status code: %v; body: %v", resp.StatusCode, string(body))
+	}
+	return nil
+}
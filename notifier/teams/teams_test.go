@@ -0,0 +1,53 @@
+package teams
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sj14/review-bot/notifier"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend(t *testing.T) {
+	_, err := New(notifier.Config{})
+	require.Error(t, err)
+
+	var got message
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		if r.URL.Path == "/invalid" {
+			http.Error(w, "invalid card", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	n, err := New(notifier.Config{Webhook: srv.URL})
+	require.NoError(t, err)
+	require.NoError(t, n.Send(notifier.Message{Text: "**PR** <at>hulk@example.com</at>"}))
+
+	require.Equal(t, "message", got.Type)
+	require.Len(t, got.Attachments, 1)
+	require.Equal(t, "application/vnd.microsoft.card.adaptive", got.Attachments[0].ContentType)
+	content := got.Attachments[0].Content
+	require.Equal(t, "AdaptiveCard", content.Type)
+	require.Equal(t, []textBlock{{Type: "TextBlock", Text: "**PR** <at>hulk@example.com</at>", Wrap: true}}, content.Body)
+	require.Equal(t, []mention{{Type: "mention", Text: "<at>hulk@example.com</at>", Mentioned: mentioned{ID: "hulk@example.com", Name: "hulk@example.com"}}}, content.MSTeams.Entities)
+
+	n, err = New(notifier.Config{Webhook: srv.URL + "/invalid"})
+	require.NoError(t, err)
+	require.ErrorContains(t, n.Send(notifier.Message{Text: "reminder"}), "invalid card")
+}
+
+func TestMentions(t *testing.T) {
+	text, entities := mentions("<at>@hulk@example.com</at> <at>hulk@example.com</at> <at>@groot</at> <at>0b5d2c4e-6a8f-4d3b-9c1e-2f7a8b9c0d1e</at>")
+	require.Equal(t, "<at>hulk@example.com</at> <at>hulk@example.com</at> groot <at>0b5d2c4e-6a8f-4d3b-9c1e-2f7a8b9c0d1e</at>", text)
+	require.Equal(t, []mention{
+		{Type: "mention", Text: "<at>hulk@example.com</at>", Mentioned: mentioned{ID: "hulk@example.com", Name: "hulk@example.com"}},
+		{Type: "mention", Text: "<at>0b5d2c4e-6a8f-4d3b-9c1e-2f7a8b9c0d1e</at>", Mentioned: mentioned{ID: "0b5d2c4e-6a8f-4d3b-9c1e-2f7a8b9c0d1e", Name: "0b5d2c4e-6a8f-4d3b-9c1e-2f7a8b9c0d1e"}},
+	}, entities)
+}
@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"errors"
+
+	"github.com/sj14/review-bot/notifier"
+	"github.com/sj14/review-bot/slackermost"
+)
+
+func init() {
+	notifier.Register("webhook", New)
+}
+
+// webhook implements notifier.Notifier for Slack and Mattermost incoming webhooks.
+type webhook struct {
+	url     string
+	channel string
+}
+
+// New returns a notifier posting to the Slack or Mattermost incoming webhook.
+func New(cfg notifier.Config) (notifier.Notifier, error) {
+	if cfg.Webhook == "" {
+		return nil, errors.New("missing webhook")
+	}
+	return &webhook{url: cfg.Webhook, channel: cfg.Channel}, nil
+}
+
+// Send posts the text of the message.
+func (w *webhook) Send(msg notifier.Message) error {
+	return slackermost.Send(w.channel, msg.Text, w.url)
+}
@@ -0,0 +1,28 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sj14/review-bot/notifier"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend(t *testing.T) {
+	_, err := New(notifier.Config{})
+	require.Error(t, err)
+
+	var payload map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+	}))
+	defer srv.Close()
+
+	n, err := New(notifier.Config{Webhook: srv.URL, Channel: "backend"})
+	require.NoError(t, err)
+	require.NoError(t, n.Send(notifier.Message{Text: "reminder"}))
+	require.Equal(t, "backend", payload["channel"])
+	require.Equal(t, "reminder", payload["text"])
+}
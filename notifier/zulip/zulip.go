@@ -0,0 +1,177 @@
+package zulip
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sj14/review-bot/hoster"
+	"github.com/sj14/review-bot/notifier"
+)
+
+const (
+	httpTimeout  = 15 * time.Second
+	defaultTopic = "review reminder"
+)
+
+func init() {
+	notifier.Register("zulip", New)
+}
+
+// zulip implements notifier.Notifier using the Zulip message API.
+type zulip struct {
+	baseURL string
+	email   string
+	apiKey  string
+	stream  string
+	topic   string
+	http    *http.Client
+}
+
+// New returns a notifier sending to the stream (channel) of the Zulip organization at the URL.
+// The token is the 'email:api-key' of the bot and the topic is set with the 'topic' option.
+func New(cfg notifier.Config) (notifier.Notifier, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("missing zulip url")
+	}
+	email, apiKey, ok := strings.Cut(cfg.Token, ":")
+	if !ok || email == "" || apiKey == "" {
+		return nil, errors.New("zulip token has to be in the format 'email:api-key'")
+	}
+	if err := cfg.Options.Check("topic"); err != nil {
+		return nil, err
+	}
+
+	return &zulip{
+		baseURL: strings.TrimSuffix(cfg.URL, "/") + "/api/v1",
+		email:   email,
+		apiKey:  apiKey,
+		stream:  cfg.Channel,
+		topic:   cfg.Options.String("topic", defaultTopic),
+		http:    &http.Client{Timeout: httpTimeout},
+	}, nil
+}
+
+// Send sends the message to the topic of the stream.
+func (z *zulip) Send(msg notifier.Message) error {
+	if z.stream == "" {
+		return errors.New("missing zulip stream")
+	}
+
+	form := url.Values{
+		"type":    {"stream"},
+		"to":      {z.stream},
+		"topic":   {z.topic},
+		"content": {mentions(convert(msg.Text), handles(msg.Projects))},
+	}
+	if err := z.post("/messages", form); err != nil {
+		return fmt.Errorf("failed sending zulip message: %w", err)
+	}
+	return nil
+}
+
+var (
+	// the size syntax of the images (e.g. =40x) is Mattermost specific
+	image = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)\s*`)
+	// horizontal rules aren't supported
+	rule   = regexp.MustCompile(`(?m)^[ \t]*(-{3,}|\*{3,}|_{3,})[ \t]*$`)
+	blanks = regexp.MustCompile(`\n{3,}`)
+)
+
+// convert removes the markdown which isn't supported by Zulip.
+func convert(md string) string {
+	md = image.ReplaceAllString(md, "")
+	md = rule.ReplaceAllString(md, "")
+	return strings.TrimSpace(blanks.ReplaceAllString(md, "\n\n"))
+}
+
+// handles returns the chat handles of the missing reviewers and owners.
+func handles(projects []hoster.Project) map[string]bool {
+	h := map[string]bool{}
+	for _, p := range projects {
+		for _, r := range p.Reminders {
+			for _, m := range r.Missing {
+				h[m] = true
+			}
+			if r.Owner != "" {
+				h[r.Owner] = true
+			}
+		}
+	}
+	return h
+}
+
+var userID = regexp.MustCompile(`^@?(\d+)$`)
+
+// mentions replaces the handles in the text with Zulip mentions,
+// '@name' becomes '@**name**' and user ids (e.g. '@42') become '@**|42**'.
+func mentions(text string, handles map[string]bool) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		words := strings.Split(line, " ")
+		for j, w := range words {
+			// e.g. 'You got all reviews, @john.'
+			handle := strings.TrimRight(w, ".,;:!?)")
+			if handles[handle] {
+				words[j] = mention(handle) + w[len(handle):]
+			}
+		}
+		lines[i] = strings.Join(words, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func mention(handle string) string {
+	if m := userID.FindStringSubmatch(handle); m != nil {
+		return "@**|" + m[1] + "**"
+	}
+	if strings.HasPrefix(handle, "@") && !strings.HasPrefix(handle, "@**") {
+		return "@**" + handle[1:] + "**"
+	}
+	return handle
+}
+
+// post sends the form to the API path.
+func (z *zulip) post(path string, form url.Values) error {
+	req, err := http.NewRequest(http.MethodPost, z.baseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(z.email, z.apiKey)
+
+	resp, err := z.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("failed to close zulip response body: %v\n", err)
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var result struct {
+		Result string `json:"result"`
+		Msg    string `json:"msg"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil || resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status code: %v; body: %v", resp.StatusCode, string(body))
+	}
+	if result.Result != "success" {
+		return fmt.Errorf("zulip: %v", result.Msg)
+	}
+	return nil
+}
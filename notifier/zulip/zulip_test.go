@@ -0,0 +1,77 @@
+package zulip
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sj14/review-bot/hoster"
+	"github.com/sj14/review-bot/notifier"
+	"github.com/sj14/review-bot/options"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(notifier.Config{Token: "bot@example.com:key"})
+	require.Error(t, err)
+
+	_, err = New(notifier.Config{URL: "https://zulip.example.com", Token: "key"})
+	require.Error(t, err)
+
+	_, err = New(notifier.Config{URL: "https://zulip.example.com", Token: "bot@example.com:key", Options: options.Options{"unknown": "1"}})
+	require.Error(t, err)
+
+	n, err := New(notifier.Config{URL: "https://zulip.example.com/", Token: "bot@example.com:key"})
+	require.NoError(t, err)
+	require.Equal(t, "https://zulip.example.com/api/v1", n.(*zulip).baseURL)
+	require.Equal(t, defaultTopic, n.(*zulip).topic)
+	require.Error(t, n.Send(notifier.Message{Text: "reminder"}), "missing stream")
+}
+
+func TestSend(t *testing.T) {
+	var form map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/messages", r.URL.Path)
+		user, pass, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "bot@example.com", user)
+		require.Equal(t, "key", pass)
+
+		require.NoError(t, r.ParseForm())
+		form = map[string]string{}
+		for k := range r.PostForm {
+			form[k] = r.PostForm.Get(k)
+		}
+
+		if form["to"] == "unknown" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"result":"error","msg":"Stream 'unknown' does not exist","code":"STREAM_DOES_NOT_EXIST"}`)
+			return
+		}
+		fmt.Fprint(w, `{"result":"success","msg":"","id":42}`)
+	}))
+	defer srv.Close()
+
+	n, err := New(notifier.Config{URL: srv.URL, Token: "bot@example.com:key", Channel: "backend", Options: options.Options{"topic": "reviews"}})
+	require.NoError(t, err)
+
+	msg := notifier.Message{
+		Text: "# ![](https://example.com/avatar.png =40x) [repo](https://example.com/repo)\n\n---\n\n**[PR0](https://example.com/0)** @hulk @42 @hulkbuster\n**[PR1](https://example.com/1)** You got all reviews, @john.doe.",
+		Projects: []hoster.Project{{Reminders: []hoster.Reminder{
+			{Missing: []string{"@hulk", "@42"}},
+			{Owner: "@john.doe"},
+		}}},
+	}
+	require.NoError(t, n.Send(msg))
+	require.Equal(t, map[string]string{
+		"type":    "stream",
+		"to":      "backend",
+		"topic":   "reviews",
+		"content": "# [repo](https://example.com/repo)\n\n**[PR0](https://example.com/0)** @**hulk** @**|42** @hulkbuster\n**[PR1](https://example.com/1)** You got all reviews, @**john.doe**.",
+	}, form)
+
+	n, err = New(notifier.Config{URL: srv.URL, Token: "bot@example.com:key", Channel: "unknown"})
+	require.NoError(t, err)
+	require.ErrorContains(t, n.Send(notifier.Message{Text: "reminder"}), "does not exist")
+}
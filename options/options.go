@@ -0,0 +1,42 @@
+// Package options contains the hoster and notifier specific settings.
+package options
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Options are hoster or notifier specific settings.
+type Options map[string]string
+
+// Check returns an error when an option is not in the list of known options.
+func (o Options) Check(known ...string) error {
+	for key := range o {
+		if !slices.Contains(known, key) {
+			return fmt.Errorf("unknown option %q (available: %v)", key, known)
+		}
+	}
+	return nil
+}
+
+// String returns the value of the option or the fallback when not set.
+func (o Options) String(key, fallback string) string {
+	if v, ok := o[key]; ok {
+		return v
+	}
+	return fallback
+}
+
+// Bool returns the boolean value of the option or the fallback when not set.
+func (o Options) Bool(key string, fallback bool) (bool, error) {
+	v, ok := o[key]
+	if !ok {
+		return fallback, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid value of option %q: %w", key, err)
+	}
+	return b, nil
+}
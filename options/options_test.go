@@ -0,0 +1,28 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions(t *testing.T) {
+	o := Options{"api": "graphql", "commented": "true", "broken": "maybe"}
+
+	require.NoError(t, o.Check("api", "commented", "broken"))
+	require.Error(t, o.Check("api"))
+
+	require.Equal(t, "graphql", o.String("api", "rest"))
+	require.Equal(t, "rest", o.String("unknown", "rest"))
+
+	b, err := o.Bool("commented", false)
+	require.NoError(t, err)
+	require.True(t, b)
+
+	b, err = o.Bool("unknown", true)
+	require.NoError(t, err)
+	require.True(t, b)
+
+	_, err = o.Bool("broken", false)
+	require.Error(t, err)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/sj14/review-bot/config"
+	"github.com/sj14/review-bot/notifier"
+)
+
+const shutdownTimeout = 30 * time.Second
+
+// serve keeps running and triggers the jobs on their schedule until SIGINT or SIGTERM is received.
+// The health endpoint is served at the given address.
+func serve(cfg *config.Config, state *notifier.State, addr string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed serving health endpoint: %w", err)
+	}
+
+	return serveContext(ctx, cfg, ln, func(job config.Job) error {
+		return runJob(cfg, state, job)
+	})
+}
+
+// serveContext triggers the jobs on their schedule with run and serves the health endpoint
+// on the listener until the context is done. Running jobs are awaited before it returns.
+func serveContext(ctx context.Context, cfg *config.Config, ln net.Listener, run func(config.Job) error) error {
+	scheduler, err := newScheduler(cfg.Jobs, run)
+	if err != nil {
+		ln.Close()
+		return err
+	}
+
+	srv := &http.Server{Handler: healthHandler(ctx), ReadHeaderTimeout: 10 * time.Second}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+		close(serverErr)
+	}()
+
+	scheduler.Start()
+	log.Printf("serving health endpoint at %v, %v jobs scheduled", ln.Addr(), len(scheduler.Entries()))
+
+	select {
+	case <-ctx.Done():
+		log.Println("shutting down")
+	case err := <-serverErr:
+		scheduler.Stop()
+		return fmt.Errorf("failed serving health endpoint: %w", err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	// wait for running jobs to finish
+	select {
+	case <-scheduler.Stop().Done():
+	case <-shutdownCtx.Done():
+		log.Println("timeout waiting for running jobs")
+	}
+
+	return srv.Shutdown(shutdownCtx)
+}
+
+// newScheduler returns a scheduler calling run for each job with a schedule.
+// Panics of a job are recovered and a job is skipped while its previous run is still running.
+func newScheduler(jobs []config.Job, run func(config.Job) error) (*cron.Cron, error) {
+	logger := cron.VerbosePrintfLogger(log.Default())
+	scheduler := cron.New(cron.WithChain(cron.Recover(logger), cron.SkipIfStillRunning(logger)))
+
+	for _, job := range jobs {
+		if job.Schedule == "" {
+			log.Printf("job %q has no schedule, skipping", job.Name)
+			continue
+		}
+
+		if _, err := scheduler.AddFunc(job.Spec(), func() {
+			log.Printf("running job %q", job.Name)
+			if err := run(job); err != nil {
+				log.Printf("job %q failed: %v", job.Name, err)
+			}
+		}); err != nil {
+			return nil, fmt.Errorf("failed scheduling job %q: %w", job.Name, err)
+		}
+	}
+
+	if len(scheduler.Entries()) == 0 {
+		return nil, errors.New("no scheduled jobs")
+	}
+
+	return scheduler, nil
+}
+
+// healthHandler reports ok until the context is done.
+func healthHandler(ctx context.Context) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		if ctx.Err() != nil {
+			http.Error(w, "shutting down", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+	return mux
+}
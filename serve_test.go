@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sj14/review-bot/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	h := healthHandler(ctx)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "ok\n", rec.Body.String())
+
+	cancel()
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestNewScheduler(t *testing.T) {
+	run := func(config.Job) error { return nil }
+
+	_, err := newScheduler([]config.Job{{Name: "manual"}}, run)
+	require.EqualError(t, err, "no scheduled jobs")
+
+	_, err = newScheduler([]config.Job{{Name: "broken", Schedule: "every day"}}, run)
+	require.ErrorContains(t, err, `failed scheduling job "broken"`)
+
+	scheduler, err := newScheduler([]config.Job{
+		{Name: "daily", Schedule: "0 9 * * 1-5", Timezone: "Europe/Berlin"},
+		{Name: "manual"},
+	}, run)
+	require.NoError(t, err)
+	require.Len(t, scheduler.Entries(), 1)
+}
+
+func TestServeContext(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	var runs atomic.Int32
+	cfg := &config.Config{Jobs: []config.Job{{Name: "frequent", Schedule: "@every 1s"}}}
+	run := func(job config.Job) error {
+		if job.Name == "frequent" {
+			runs.Add(1)
+		}
+		return errors.New("failed jobs don't stop the server")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- serveContext(ctx, cfg, ln, run) }()
+
+	url := "http://" + ln.Addr().String() + "/healthz"
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(url)
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Eventually(t, func() bool { return runs.Load() >= 2 }, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no shutdown after the context was cancelled")
+	}
+
+	_, err = http.Get(url)
+	require.Error(t, err, "server still running after shutdown")
+}